@@ -10,7 +10,9 @@ go mod tidy
 
 # Build the daemon
 Write-Host "Daemon derleniyor..."
-go build -o syswatch-daemon.exe ./cmd/syswatch-daemon
+$version = git describe --tags --always --dirty 2>$null
+if (-not $version) { $version = "dev" }
+go build -ldflags "-X main.version=$version" -o syswatch-daemon.exe ./cmd/syswatch-daemon
 
 if ($LASTEXITCODE -eq 0) {
     Write-Host "✅ Build başarılı!"
@@ -8,7 +8,8 @@ echo "Go modülleri indiriliyor..."
 go mod tidy
 
 echo "Daemon derleniyor..."
-go build -o syswatch-daemon ./cmd/syswatch-daemon
+VERSION=$(git describe --tags --always --dirty 2>/dev/null || echo dev)
+go build -ldflags "-X main.version=$VERSION" -o syswatch-daemon ./cmd/syswatch-daemon
 
 if [ $? -eq 0 ]; then
     echo "✅ Build başarılı!"
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/daemon"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+)
+
+// Çıkış kodları
+const (
+	exitOK          = 0
+	exitConfigError = 1
+	exitStartError  = 2
+	exitStopError   = 3
+)
+
+// version derleme sırasında -ldflags "-X main.version=..." ile ayarlanan sürüm
+var version = "dev"
+
+// shutdownTimeout daemon'un temiz şekilde kapanması için verilen süre
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	os.Exit(run())
+}
+
+// run uygulamayı çalıştırır ve çıkış kodunu döndürür
+func run() int {
+	// Alt komutlar daemon'u başlatmaz
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		return runConfig(os.Args[2:])
+	}
+
+	configPath := flag.String("config", "config.json", "konfigürasyon dosyasının yolu")
+	logLevel := flag.String("log-level", "", "log level (debug, info, warn, error); config dosyasındaki değeri ezer")
+	port := flag.Int("port", 0, "dashboard portu; config dosyasındaki değeri ezer")
+	printConfig := flag.Bool("print-config", false, "dosya, SYSWATCH_ ortam değişkenleri ve parametreler uygulanmış etkin konfigürasyonu yazdırıp çık")
+	printOrigins := flag.Bool("print-origins", false, "varsayılandan farklı her config değerinin hangi dosya, ortam değişkeni veya parametreden geldiğini yazdırıp çık")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Kullanım: %s [seçenekler]\n          "+configUsage+"\n\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Yazdırılan config'e log satırları karışmasın
+	if *printConfig || *printOrigins {
+		logger.Configure(logger.Options{Output: "stderr"})
+	}
+
+	log := logger.GetLogger()
+
+	// loadConfig config dosyasını okur; komut satırı parametreleri dosyadaki değerleri ezer.
+	// Yeniden yüklemelerde de aynı parametreler uygulanır.
+	loadConfig := func() (*config.Config, error) {
+		cfg, err := config.Load(*configPath)
+		if err != nil {
+			return nil, err
+		}
+		if *logLevel != "" {
+			cfg.Logging.Level = *logLevel
+			cfg.SetOrigin("logging.level", "flag:-log-level")
+		}
+		if *port != 0 {
+			cfg.Dashboard.Port = *port
+			cfg.SetOrigin("dashboard.port", "flag:-port")
+		}
+		return cfg, nil
+	}
+
+	// Konfigürasyonu yükle
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Errorf("Konfigürasyon yüklenemedi: %v", err)
+		return exitConfigError
+	}
+
+	if *printConfig {
+		data, err := cfg.Marshal(config.FormatFromPath(*configPath))
+		if err != nil {
+			log.Errorf("Konfigürasyon yazdırılamadı: %v", err)
+			return exitConfigError
+		}
+		fmt.Println(strings.TrimRight(string(data), "\n"))
+		return exitOK
+	}
+
+	if *printOrigins {
+		origins := cfg.Origins()
+		paths := make([]string, 0, len(origins))
+		for path := range origins {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			fmt.Printf("%s\t%s\n", path, origins[path])
+		}
+		return exitOK
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Errorf("Konfigürasyon geçersiz: %v", err)
+		return exitConfigError
+	}
+
+	if err := logger.Configure(cfg.Logging.Options()); err != nil {
+		log.Errorf("Logger yapılandırılamadı: %v", err)
+		return exitConfigError
+	}
+	defer logger.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Sinyaller Start'tan önce yakalanır: WAL kurtarma ve depolama açılışı sürerken
+	// gelen SIGTERM kapanışı atlatmasın, SIGHUP süreci öldürmesin. Bu sırada gelen
+	// sinyal kanalda bekler ve Start bittikten sonra işlenir.
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	d := daemon.NewWithConfig(cfg)
+	d.SetConfigLoader(*configPath, loadConfig)
+	d.SetVersion(version)
+	log.Infof("syswatch-daemon %s başlatılıyor", version)
+	if err := d.Start(ctx); err != nil {
+		log.Errorf("Daemon başlatılamadı: %v", err)
+		return exitStartError
+	}
+
+	// SIGINT/SIGTERM gelene kadar bekle; SIGHUP config'i yeniden yükler
+	for sig := range sigChan {
+		if sig == syscall.SIGHUP {
+			d.ReloadFromSource("sighup")
+			continue
+		}
+		log.Infof("Sinyal alındı: %v", sig)
+		break
+	}
+
+	// Sınırlı süreli shutdown
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+
+	if err := d.Stop(shutdownCtx); err != nil {
+		log.Errorf("Daemon durdurulurken hata: %v", err)
+		return exitStopError
+	}
+
+	return exitOK
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+)
+
+// configUsage config alt komutlarının kullanımı (%s program ismi)
+const configUsage = "%s config validate [-config dosya]"
+
+// runConfig "config" alt komutlarını çalıştırır
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "validate" {
+		fmt.Fprintf(os.Stderr, "Kullanım: "+configUsage+"\n", os.Args[0])
+		return exitConfigError
+	}
+	return runConfigValidate(args[1:])
+}
+
+// runConfigValidate config dosyasını parçaları ve ortam değişkenleriyle birlikte
+// doğrular, bulunan tüm hataları ve uyarıları json yollarıyla yazdırır. Uyarılar
+// (kullanımdan kalkan alanlar vb.) çıkış kodunu etkilemez.
+func runConfigValidate(args []string) int {
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	configPath := fs.String("config", "config.json", "doğrulanacak konfigürasyon dosyasının yolu")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Kullanım: "+configUsage+"\n\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitConfigError
+	}
+
+	// Yükleme logları ve uyarıları rapora karışmasın
+	logger.Configure(logger.Options{Level: "error", Output: "stderr"})
+
+	// Load eksik dosyada varsayılanlara döner; doğrulamada bu bir hatadır
+	if _, err := os.Stat(*configPath); err != nil {
+		fmt.Printf("hata: konfigürasyon dosyası okunamadı: %v\n", err)
+		return exitConfigError
+	}
+
+	errs, warnings, err := config.ValidateFile(*configPath)
+	if err != nil {
+		fmt.Printf("hata: %v\n", err)
+		return exitConfigError
+	}
+	for _, issue := range errs {
+		fmt.Printf("hata: %s\n", issue)
+	}
+	for _, issue := range warnings {
+		fmt.Printf("uyarı: %s\n", issue)
+	}
+
+	if len(errs) > 0 {
+		fmt.Printf("%s: %d hata, %d uyarı\n", *configPath, len(errs), len(warnings))
+		return exitConfigError
+	}
+	fmt.Printf("%s: geçerli (%d uyarı)\n", *configPath, len(warnings))
+	return exitOK
+}
@@ -0,0 +1,355 @@
+// Package alert snapshot'ları eşik tabanlı kurallara göre değerlendirir.
+package alert
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// State alarmın durumu
+type State string
+
+// Alarm durumları
+const (
+	StatePending  State = "pending"  // Koşul sağlanıyor, "for" süresi henüz dolmadı
+	StateFiring   State = "firing"   // Alarm aktif
+	StateResolved State = "resolved" // Alarm kapandı
+)
+
+// Önem seviyeleri
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+const (
+	// resolvedRetention kapanan alarmların listede kalma süresi
+	resolvedRetention = 15 * time.Minute
+
+	// staleAfter metriği bu süre boyunca görülmeyen aktif alarm kapatılır
+	// (ör. kaldırılan bir mountpoint); daha kısa kesintiler durumu değiştirmez
+	staleAfter = 5 * time.Minute
+)
+
+// Alert bir kuralın tek bir metrik yolu için oluşturduğu alarm
+type Alert struct {
+	Rule        string            `json:"rule"`
+	Metric      string            `json:"metric"` // Eşleşen alan yolu
+	State       State             `json:"state"`
+	Severity    string            `json:"severity"`
+	Summary     string            `json:"summary"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Value       float64           `json:"value"`
+	Threshold   float64           `json:"threshold"`
+	ActiveSince time.Time         `json:"active_since"`          // Koşulun ilk sağlandığı zaman
+	FiredAt     *time.Time        `json:"fired_at,omitempty"`    // Pending'den firing'e geçiş
+	ResolvedAt  *time.Time        `json:"resolved_at,omitempty"` // Firing'den resolved'a geçiş
+
+	lastSeen time.Time // Metriğin son görüldüğü snapshot zamanı
+}
+
+// Key alarmı benzersiz olarak tanımlayan anahtar
+func (a *Alert) Key() string {
+	return a.Rule + "/" + a.Metric
+}
+
+// rule derlenmiş alarm kuralı
+type rule struct {
+	config.AlertRuleConfig
+	selector *regexp.Regexp // Joker içeren yollar için; nil ise tam eşleşme
+	clear    float64
+}
+
+// Engine alarm kurallarını her snapshot'ta değerlendirir ve alarm durumlarını tutar
+type Engine struct {
+	mu     sync.RWMutex
+	rules  []*rule
+	alerts map[string]*Alert
+}
+
+// NewEngine config'deki kurallarla yeni alarm motoru oluşturur
+func NewEngine(rules []config.AlertRuleConfig) *Engine {
+	return &Engine{rules: compileRules(rules), alerts: make(map[string]*Alert)}
+}
+
+// SetRules kuralları değiştirir. Aynı isimle devam eden kuralların alarmları
+// korunur ve bir sonraki değerlendirmede yeni eşiklerle kontrol edilir;
+// kaldırılan kuralların alarmları silinir.
+func (e *Engine) SetRules(rules []config.AlertRuleConfig) {
+	compiled := compileRules(rules)
+	names := make(map[string]bool, len(compiled))
+	for _, r := range compiled {
+		names[r.Name] = true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rules = compiled
+	for key, a := range e.alerts {
+		if !names[a.Rule] {
+			delete(e.alerts, key)
+		}
+	}
+}
+
+// compileRules config'deki kuralları derler
+func compileRules(rules []config.AlertRuleConfig) []*rule {
+	compiled := make([]*rule, 0, len(rules))
+	for _, rc := range rules {
+		r := &rule{AlertRuleConfig: rc, clear: rc.Threshold}
+		if rc.Clear != nil {
+			r.clear = *rc.Clear
+		}
+		if r.Severity == "" {
+			r.Severity = SeverityWarning
+		}
+		if strings.Contains(rc.Metric, "*") {
+			// Her * bir yol parçası yakalar; mountpoint gibi değerler nokta içerebilir
+			parts := strings.Split(rc.Metric, "*")
+			for i := range parts {
+				parts[i] = regexp.QuoteMeta(parts[i])
+			}
+			r.selector = regexp.MustCompile("^" + strings.Join(parts, "(.+)") + "$")
+		}
+		compiled = append(compiled, r)
+	}
+	return compiled
+}
+
+// Evaluate snapshot'ı tüm kurallara göre değerlendirir ve durumu değişen
+// alarmların kopyalarını döndürür (yeni pending, firing ve resolved geçişleri)
+func (e *Engine) Evaluate(m *metrics.SystemMetrics) []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := m.Timestamp
+	values := m.Flatten()
+	var changed []Alert
+
+	for _, r := range e.rules {
+		for path, value := range r.match(values) {
+			if a := e.evaluate(r, path, value, now); a != nil {
+				changed = append(changed, *a)
+			}
+		}
+	}
+
+	// Metriği artık görülmeyen alarmlar
+	for key, a := range e.alerts {
+		if !a.lastSeen.Before(now) {
+			continue
+		}
+		switch a.State {
+		case StatePending:
+			delete(e.alerts, key)
+		case StateFiring:
+			if now.Sub(a.lastSeen) >= staleAfter {
+				a.resolve(now)
+				changed = append(changed, *a)
+			}
+		case StateResolved:
+			if now.Sub(*a.ResolvedAt) >= resolvedRetention {
+				delete(e.alerts, key)
+			}
+		}
+	}
+
+	sortAlerts(changed)
+	return changed
+}
+
+// evaluate tek bir metrik değeri için alarm durumunu günceller.
+// Durum değiştiyse alarmı döndürür.
+func (e *Engine) evaluate(r *rule, path string, value float64, now time.Time) *Alert {
+	key := r.Name + "/" + path
+	a, exists := e.alerts[key]
+	if exists {
+		a.Value = value
+		a.lastSeen = now
+	}
+
+	active := exists && (a.State == StatePending || a.State == StateFiring)
+	if !active {
+		if !compare(value, r.Op, r.Threshold) {
+			if exists && now.Sub(*a.ResolvedAt) >= resolvedRetention {
+				delete(e.alerts, key)
+			}
+			return nil
+		}
+		a = &Alert{
+			Rule:        r.Name,
+			Metric:      path,
+			State:       StatePending,
+			Severity:    r.Severity,
+			Summary:     r.summary(path),
+			Labels:      r.labels(path),
+			Value:       value,
+			Threshold:   r.Threshold,
+			ActiveSince: now,
+			lastSeen:    now,
+		}
+		e.alerts[key] = a
+		if r.For > 0 {
+			return a
+		}
+	}
+
+	switch a.State {
+	case StatePending:
+		if !compare(value, r.Op, r.Threshold) {
+			// Süre dolmadan düzelen koşul alarm üretmez
+			delete(e.alerts, key)
+			return nil
+		}
+		if now.Sub(a.ActiveSince) >= time.Duration(r.For)*time.Second {
+			a.State = StateFiring
+			a.FiredAt = &now
+			return a
+		}
+
+	case StateFiring:
+		// Hysteresis: alarm, değer kapanma eşiğinin iyi tarafına geçene kadar açık kalır
+		if !compare(value, r.Op, r.clear) {
+			a.resolve(now)
+			return a
+		}
+	}
+
+	return nil
+}
+
+// resolve alarmı kapatır
+func (a *Alert) resolve(now time.Time) {
+	a.State = StateResolved
+	a.ResolvedAt = &now
+}
+
+// Alerts aktif ve yakın zamanda kapanmış alarmları döndürür
+func (e *Engine) Alerts() []Alert {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	result := make([]Alert, 0, len(e.alerts))
+	for _, a := range e.alerts {
+		result = append(result, *a)
+	}
+	sortAlerts(result)
+	return result
+}
+
+// Firing sadece aktif (firing) alarmları döndürür
+func (e *Engine) Firing() []Alert {
+	var firing []Alert
+	for _, a := range e.Alerts() {
+		if a.State == StateFiring {
+			firing = append(firing, a)
+		}
+	}
+	return firing
+}
+
+// match kuralın seçicisine uyan alan yollarını değerleriyle döndürür
+func (r *rule) match(values map[string]float64) map[string]float64 {
+	matched := make(map[string]float64)
+	if r.selector == nil {
+		if v, ok := values[r.Metric]; ok {
+			matched[r.Metric] = v
+		}
+		return matched
+	}
+	for path, v := range values {
+		if r.selector.MatchString(path) {
+			matched[path] = v
+		}
+	}
+	return matched
+}
+
+// labels kuralın etiketlerine joker ile yakalanan değeri (instance) ekler
+func (r *rule) labels(path string) map[string]string {
+	labels := make(map[string]string, len(r.Labels)+1)
+	for k, v := range r.Labels {
+		labels[k] = v
+	}
+	if r.selector != nil {
+		if groups := r.selector.FindStringSubmatch(path); len(groups) > 1 {
+			labels["instance"] = strings.Join(groups[1:], ",")
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// summary alarm açıklamasını oluşturur
+func (r *rule) summary(path string) string {
+	if r.Summary != "" {
+		return r.Summary
+	}
+	return fmt.Sprintf("%s %s %g", path, r.Op, r.Threshold)
+}
+
+// compare değeri operatöre göre eşikle karşılaştırır
+func compare(value float64, op string, threshold float64) bool {
+	switch op {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "==":
+		return value == threshold
+	case "!=":
+		return value != threshold
+	}
+	return false
+}
+
+// severityRank önem seviyesini sıralama için sayıya çevirir
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 0
+	case SeverityWarning:
+		return 1
+	}
+	return 2
+}
+
+// stateRank durumu sıralama için sayıya çevirir
+func stateRank(state State) int {
+	switch state {
+	case StateFiring:
+		return 0
+	case StatePending:
+		return 1
+	}
+	return 2
+}
+
+// sortAlerts alarmları durum, önem ve anahtara göre sıralar
+func sortAlerts(alerts []Alert) {
+	sort.Slice(alerts, func(i, j int) bool {
+		a, b := alerts[i], alerts[j]
+		if stateRank(a.State) != stateRank(b.State) {
+			return stateRank(a.State) < stateRank(b.State)
+		}
+		if severityRank(a.Severity) != severityRank(b.Severity) {
+			return severityRank(a.Severity) < severityRank(b.Severity)
+		}
+		return a.Key() < b.Key()
+	})
+}
@@ -0,0 +1,148 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// cpuSnapshot sadece CPU kullanımı içeren snapshot oluşturur
+func cpuSnapshot(t time.Time, usage float64) *metrics.SystemMetrics {
+	return &metrics.SystemMetrics{Timestamp: t, CPU: &metrics.CPUMetrics{Usage: usage}}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestAlertLifecycle(t *testing.T) {
+	engine := NewEngine([]config.AlertRuleConfig{
+		{Name: "high_cpu", Metric: "cpu.usage", Op: ">", Threshold: 90, Clear: floatPtr(80), For: 60, Severity: "critical"},
+	})
+	base := time.Now()
+
+	steps := []struct {
+		offset     time.Duration
+		usage      float64
+		transition State // Boşsa durum değişmemeli
+		state      State // Adımdan sonraki durum; boşsa alarm olmamalı
+	}{
+		{0, 50, "", ""},
+		{10 * time.Second, 95, StatePending, StatePending},
+		{40 * time.Second, 96, "", StatePending},
+		{70 * time.Second, 97, StateFiring, StateFiring},
+		// Eşiğin altına indi ama kapanma eşiğinin üstünde: açık kalmalı
+		{80 * time.Second, 85, "", StateFiring},
+		{90 * time.Second, 75, StateResolved, StateResolved},
+		// Kapanan alarmın yerine yeni pending başlamalı
+		{100 * time.Second, 99, StatePending, StatePending},
+		// Süre dolmadan düzelen pending sessizce silinmeli
+		{110 * time.Second, 10, "", ""},
+	}
+
+	for i, step := range steps {
+		changed := engine.Evaluate(cpuSnapshot(base.Add(step.offset), step.usage))
+
+		if step.transition == "" && len(changed) != 0 {
+			t.Errorf("Step %d: expected no transition, got %v", i, changed[0].State)
+		}
+		if step.transition != "" && (len(changed) != 1 || changed[0].State != step.transition) {
+			t.Errorf("Step %d: expected transition to %s, got %v", i, step.transition, changed)
+		}
+
+		alerts := engine.Alerts()
+		if step.state == "" {
+			if len(alerts) != 0 {
+				t.Errorf("Step %d: expected no alerts, got %s", i, alerts[0].State)
+			}
+			continue
+		}
+		if len(alerts) != 1 || alerts[0].State != step.state {
+			t.Errorf("Step %d: expected state %s, got %v", i, step.state, alerts)
+		}
+	}
+}
+
+func TestAlertWildcard(t *testing.T) {
+	engine := NewEngine([]config.AlertRuleConfig{
+		{Name: "disk_full", Metric: "disk.filesystems.*.usage", Op: ">=", Threshold: 90, Labels: map[string]string{"team": "ops"}},
+	})
+
+	m := &metrics.SystemMetrics{
+		Timestamp: time.Now(),
+		Disk: &metrics.DiskMetrics{Filesystems: []metrics.FilesystemMetrics{
+			{Mountpoint: "/", Usage: 50},
+			{Mountpoint: "/mnt/data.vol", Usage: 95},
+		}},
+	}
+
+	// for = 0: koşul sağlandığı anda firing olmalı
+	changed := engine.Evaluate(m)
+	if len(changed) != 1 || changed[0].State != StateFiring {
+		t.Fatalf("Expected one firing alert, got %v", changed)
+	}
+
+	a := changed[0]
+	if a.Metric != "disk.filesystems./mnt/data.vol.usage" {
+		t.Errorf("Unexpected metric path: %s", a.Metric)
+	}
+	if a.Labels["instance"] != "/mnt/data.vol" || a.Labels["team"] != "ops" {
+		t.Errorf("Unexpected labels: %v", a.Labels)
+	}
+	if a.Severity != SeverityWarning {
+		t.Errorf("Expected default severity warning, got %s", a.Severity)
+	}
+	if len(engine.Firing()) != 1 {
+		t.Errorf("Expected 1 firing alert, got %d", len(engine.Firing()))
+	}
+}
+
+func TestAlertStale(t *testing.T) {
+	engine := NewEngine([]config.AlertRuleConfig{
+		{Name: "high_cpu", Metric: "cpu.usage", Op: ">", Threshold: 90},
+	})
+	base := time.Now()
+
+	engine.Evaluate(cpuSnapshot(base, 95))
+
+	// Kısa veri kesintisi alarmı kapatmamalı
+	missing := &metrics.SystemMetrics{Timestamp: base.Add(time.Minute)}
+	if changed := engine.Evaluate(missing); len(changed) != 0 {
+		t.Errorf("Expected firing alert to survive short gap, got %v", changed)
+	}
+
+	missing.Timestamp = base.Add(staleAfter + time.Second)
+	changed := engine.Evaluate(missing)
+	if len(changed) != 1 || changed[0].State != StateResolved {
+		t.Errorf("Expected stale alert to resolve, got %v", changed)
+	}
+}
+
+func TestSetRules(t *testing.T) {
+	engine := NewEngine([]config.AlertRuleConfig{
+		{Name: "high_cpu", Metric: "cpu.usage", Op: ">", Threshold: 90},
+		{Name: "busy_cpu", Metric: "cpu.usage", Op: ">", Threshold: 50},
+	})
+	base := time.Now()
+	engine.Evaluate(cpuSnapshot(base, 95))
+	if alerts := engine.Alerts(); len(alerts) != 2 {
+		t.Fatalf("Expected 2 firing alerts, got %d", len(alerts))
+	}
+
+	// Kalan kuralın alarmı korunmalı, kaldırılan kuralınki silinmeli
+	engine.SetRules([]config.AlertRuleConfig{
+		{Name: "high_cpu", Metric: "cpu.usage", Op: ">", Threshold: 99},
+	})
+	alerts := engine.Alerts()
+	if len(alerts) != 1 || alerts[0].Rule != "high_cpu" || alerts[0].State != StateFiring {
+		t.Fatalf("Expected only high_cpu to remain firing, got %v", alerts)
+	}
+
+	// Yeni eşik sonraki değerlendirmede kullanılmalı
+	changed := engine.Evaluate(cpuSnapshot(base.Add(time.Second), 95))
+	if len(changed) != 1 || changed[0].State != StateResolved {
+		t.Errorf("Expected alert to resolve with new threshold, got %v", changed)
+	}
+}
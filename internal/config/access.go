@@ -0,0 +1,13 @@
+//go:build !windows
+
+package config
+
+import "syscall"
+
+// wOK access(2) için yazma izni kontrol bayrağı
+const wOK = 0x2
+
+// canWrite sürecin yola yazma izni olup olmadığını dosya sistemine dokunmadan kontrol eder
+func canWrite(path string) error {
+	return syscall.Access(path, wOK)
+}
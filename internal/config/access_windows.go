@@ -0,0 +1,21 @@
+//go:build windows
+
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// canWrite Windows'ta yalnızca salt okunur dosya özniteliğini kontrol eder;
+// dizinlerdeki salt okunur özniteliği dosya oluşturmayı engellemez
+func canWrite(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() && info.Mode().Perm()&0200 == 0 {
+		return fmt.Errorf("%s salt okunur", path)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -22,12 +23,30 @@ type Config struct {
 	
 	// Metrics ayarları  
 	Metrics MetricsConfig `json:"metrics"`
+	
+	// Bellek içi metrik geçmişi ayarları
+	History HistoryConfig `json:"history"`
+	
+	// Kalıcı metrik depolama ayarları
+	Storage StorageConfig `json:"storage"`
+	
+	// Alarm kuralları
+	Alerts AlertsConfig `json:"alerts"`
+	
+	// Ana dosyadan sonra isim sırasıyla birleştirilecek config parçalarının dizini
+	// (ana dosyanın dizinine göre; ör. "conf.d")
+	Include string `json:"include,omitempty"`
+	
+	origins  map[string]string // json yolu -> değerin kaynağı (dosya, env:..., flag)
+	files    []string          // Okunan config dosyaları
+	warnings []Issue           // Yükleme sırasında bulunan uyarılar (kullanımdan kalkan alanlar vb.)
 }
 
 // DaemonConfig daemon ayarları
 type DaemonConfig struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"` // Kullanımdan kalktı; sürüm binary'den alınır
+	WatchConfig bool   `json:"watch_config"`      // Config dosyası değiştiğinde otomatik yeniden yükle
 }
 
 // DashboardConfig dashboard ayarları
@@ -35,31 +54,197 @@ type DashboardConfig struct {
 	Enabled bool   `json:"enabled"`
 	Port    int    `json:"port"`
 	Host    string `json:"host"`
+	
+	// Susturma ve reload gibi durum değiştiren API isteklerinde istenecek token
+	// (Authorization: Bearer). Boşsa sadece aynı origin'den gelen JSON istekleri kabul edilir.
+	AdminToken string `json:"admin_token,omitempty"`
 }
 
 // LoggingConfig logging ayarları
 type LoggingConfig struct {
 	Level      string `json:"level"`       // debug, info, warn, error
 	Format     string `json:"format"`      // text, json
-	Output     string `json:"output"`      // stdout, file
+	Output     string `json:"output"`      // stdout, stderr, file
 	Filename   string `json:"filename"`    // log dosyası adı (output=file ise)
+	
+	// Dosya rotasyonu (output=file ise); SIGUSR1 ile dosya yeniden açılır
+	MaxSizeMB   int  `json:"max_size_mb"`   // Bu boyutu aşan dosya döndürülür (0 = sınırsız)
+	MaxAgeHours int  `json:"max_age_hours"` // Bu süreden eski dosya döndürülür (0 = sınırsız)
+	MaxBackups  int  `json:"max_backups"`   // Saklanacak eski dosya sayısı (0 = tümü)
+	Compress    bool `json:"compress"`      // Döndürülen dosyalar gzip ile sıkıştırılır
+}
+
+// Options logging config'ini logger ayarlarına dönüştürür
+func (l LoggingConfig) Options() logger.Options {
+	return logger.Options{
+		Level:       l.Level,
+		Format:      l.Format,
+		Output:      l.Output,
+		Filename:    l.Filename,
+		MaxSizeMB:   l.MaxSizeMB,
+		MaxAgeHours: l.MaxAgeHours,
+		MaxBackups:  l.MaxBackups,
+		Compress:    l.Compress,
+	}
 }
 
 // MetricsConfig metrics ayarları
 type MetricsConfig struct {
 	Interval     int  `json:"interval"`      // Metrik toplama aralığı (saniye)
+	EnableHost   bool `json:"enable_host"`    // Load average, uptime
 	EnableCPU    bool `json:"enable_cpu"`
 	EnableMemory bool `json:"enable_memory"`
 	EnableDisk   bool `json:"enable_disk"`
 	EnableNet    bool `json:"enable_network"`
+	EnableDiskIO bool `json:"enable_disk_io"`
+	
+	// Ağ interface filtreleri (glob desenleri, örn. "lo", "veth*")
+	NetInclude []string `json:"net_include,omitempty"` // Boşsa tüm interface'ler dahil
+	NetExclude []string `json:"net_exclude,omitempty"` // Include'dan önce uygulanır
+	
+	// Dosya sistemi filtreleri (mountpoint ve fstype için glob desenleri)
+	DiskInclude       []string `json:"disk_include,omitempty"`        // Boşsa tüm mountpoint'ler dahil
+	DiskExclude       []string `json:"disk_exclude,omitempty"`
+	DiskFstypeInclude []string `json:"disk_fstype_include,omitempty"` // Boşsa tüm fstype'lar dahil
+	DiskFstypeExclude []string `json:"disk_fstype_exclude,omitempty"`
+	
+	// Dosya sistemi büyüme hızı ve dolma süresi tahmini için geçmiş penceresi (saniye, 0 = kapalı)
+	DiskForecastWindow int `json:"disk_forecast_window"`
+	
+	// Disk I/O cihaz filtreleri (glob desenleri, örn. "sd*", "loop*")
+	DiskIOInclude []string `json:"disk_io_include,omitempty"` // Boşsa tüm cihazlar dahil
+	DiskIOExclude []string `json:"disk_io_exclude,omitempty"`
+	
+	// Process collector ayarları
+	EnableProcesses      bool     `json:"enable_processes"`
+	ProcessTopN          int      `json:"process_top_n"`          // Her sıralama için listelenecek process sayısı
+	ProcessSortBy        []string `json:"process_sort_by"`        // cpu, memory, threads
+	ProcessRedactCmdline bool     `json:"process_redact_cmdline"` // password/token gibi argüman değerlerini maskele
+	
+	// Çalışır durumda olması gereken process'ler
+	Watchlist []ProcessWatchConfig `json:"watchlist,omitempty"`
+	
+	// Etkinleştirilecek eklenti metrik kaynakları
+	Sources []SourceConfig `json:"sources,omitempty"`
+}
+
+// SourceConfig bir eklenti metrik kaynağının ayarları
+type SourceConfig struct {
+	Type    string                 `json:"type"`              // Kayıtlı kaynak ismi (ör. "file")
+	ID      string                 `json:"id,omitempty"`      // Aynı tipten birden fazla kaynak için benzersiz isim
+	Timeout int                    `json:"timeout,omitempty"` // Toplama zaman aşımı (saniye), varsayılan interval
+	Options map[string]interface{} `json:"options,omitempty"` // Kaynağa özel seçenekler
+}
+
+// ProcessWatchConfig izlenecek bir process tanımı.
+// Pidfile verilmişse sadece o kullanılır; aksi halde verilen tüm kriterler birlikte eşleşmelidir.
+type ProcessWatchConfig struct {
+	Name        string `json:"name"`                   // Raporlarda görünen isim
+	ProcessName string `json:"process_name,omitempty"` // Process ismi (ör. "nginx")
+	Exe         string `json:"exe,omitempty"`          // Çalıştırılabilir dosyanın tam yolu
+	Cmdline     string `json:"cmdline,omitempty"`      // Komut satırına uygulanacak regex
+	Pidfile     string `json:"pidfile,omitempty"`      // PID dosyasının yolu
+}
+
+// HistoryConfig bellek içi metrik geçmişi ayarları
+type HistoryConfig struct {
+	Enabled    bool `json:"enabled"`
+	Retention  int  `json:"retention"`   // Saklama süresi (saniye)
+	MaxSamples int  `json:"max_samples"` // En fazla snapshot sayısı (0 ise retention/interval'dan hesaplanır)
+}
+
+// StorageConfig diskteki kalıcı metrik depolama ayarları
+type StorageConfig struct {
+	Enabled       bool   `json:"enabled"`
+	DataDir       string `json:"data_dir"`       // Veri dizini
+	Retention     int    `json:"retention"`      // Saklama süresi (saniye, 0 = süresiz)
+	MaxDiskMB     int    `json:"max_disk_mb"`    // Ham veriler için disk bütçesi (MB, 0 = sınırsız)
+	BlockDuration int    `json:"block_duration"` // Her block'un kapsadığı süre (saniye)
+	
+	// Uzun dönem geçmiş için özetleme katmanları
+	Rollups []RollupConfig `json:"rollups"`
+}
+
+// AlertsConfig alarm motoru ayarları
+type AlertsConfig struct {
+	Enabled      bool                      `json:"enabled"`
+	Rules        []AlertRuleConfig         `json:"rules"`
+	Channels     []NotifyChannelConfig     `json:"channels,omitempty"`    // Alarm bildirim kanalları
+	SilencesFile string                    `json:"silences_file"`         // API ile oluşturulan susturmaların saklandığı dosya (boşsa saklanmaz)
+	Maintenance  []MaintenanceWindowConfig `json:"maintenance,omitempty"` // Tekrarlayan bakım pencereleri
+}
+
+// MaintenanceWindowConfig tekrarlayan bir bakım penceresi. Pencere süresince
+// eşleşen alarmların durumu izlenmeye devam eder ama bildirim gönderilmez.
+type MaintenanceWindowConfig struct {
+	Name     string            `json:"name"`
+	Schedule string            `json:"schedule"`         // Başlangıç zamanları (cron: dakika saat gün ay haftanın-günü)
+	Duration int               `json:"duration"`         // Pencere süresi (saniye)
+	Rules    []string          `json:"rules,omitempty"`  // Etkilenen kurallar (* joker); boşsa tümü
+	Labels   map[string]string `json:"labels,omitempty"` // Alarmda bulunması gereken etiketler
+}
+
+// NotifyChannelConfig bir alarm bildirim kanalının ayarları
+type NotifyChannelConfig struct {
+	Name        string                 `json:"name"`                   // Loglarda ve API'de görünen benzersiz isim
+	Type        string                 `json:"type"`                   // webhook, smtp, syslog, exec
+	States      []string               `json:"states,omitempty"`       // Bildirilecek durumlar (varsayılan firing, resolved)
+	MinSeverity string                 `json:"min_severity,omitempty"` // Bu seviyenin altındaki alarmlar bildirilmez
+	Timeout     int                    `json:"timeout,omitempty"`      // Tek gönderim denemesinin zaman aşımı (saniye, varsayılan 10)
+	Retries     int                    `json:"retries,omitempty"`      // Başarısız gönderimin yeniden deneme sayısı
+	Options     map[string]interface{} `json:"options,omitempty"`      // Kanala özel seçenekler
+}
+
+// AlertRuleConfig eşik tabanlı bir alarm kuralı
+type AlertRuleConfig struct {
+	Name      string            `json:"name"`
+	Metric    string            `json:"metric"`          // Alan yolu; * joker karakteri kullanılabilir (disk.filesystems.*.usage)
+	Op        string            `json:"op"`              // >, >=, <, <=, ==, !=
+	Threshold float64           `json:"threshold"`
+	Clear     *float64          `json:"clear,omitempty"` // Alarmın kapanması için eşik (hysteresis, varsayılan threshold)
+	For       int               `json:"for"`             // Koşulun alarm vermeden önce sürmesi gereken süre (saniye)
+	Severity  string            `json:"severity"`        // info, warning, critical
+	Summary   string            `json:"summary,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// UnmarshalJSON kuralı sıfırdan ve bilinmeyen alanları reddederek çözer.
+// encoding/json varsayılan listedeki elemanları yeniden kullandığından, aksi
+// halde dosyada verilmeyen alanlar (ör. clear) varsayılan kuraldan kalırdı.
+func (r *AlertRuleConfig) UnmarshalJSON(data []byte) error {
+	type plain AlertRuleConfig
+	var v plain
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	*r = AlertRuleConfig(v)
+	return nil
+}
+
+// RollupConfig bir özetleme (downsampling) katmanı
+type RollupConfig struct {
+	Resolution int `json:"resolution"` // Özet aralığı (saniye)
+	Retention  int `json:"retention"`  // Saklama süresi (saniye, 0 = süresiz)
+}
+
+// Capacity verilen toplama aralığına göre saklanacak en fazla snapshot sayısını döndürür
+func (h HistoryConfig) Capacity(interval int) int {
+	if h.MaxSamples > 0 {
+		return h.MaxSamples
+	}
+	if interval < 1 {
+		interval = 1
+	}
+	return h.Retention/interval + 1
 }
 
 // Default varsayılan konfigürasyon
 func Default() *Config {
 	return &Config{
 		Daemon: DaemonConfig{
-			Name:    "syswatch-daemon",
-			Version: "0.1.0",
+			Name: "syswatch-daemon",
 		},
 		Dashboard: DashboardConfig{
 			Enabled: true,
@@ -67,49 +252,153 @@ func Default() *Config {
 			Host:    "localhost",
 		},
 		Logging: LoggingConfig{
-			Level:  "info",
-			Format: "text",
-			Output: "stdout",
+			Level:      "info",
+			Format:     "text",
+			Output:     "stdout",
+			Filename:   "syswatch.log",
+			MaxSizeMB:  100,
+			MaxBackups: 5,
+			Compress:   true,
 		},
 		Metrics: MetricsConfig{
-			Interval:     5,
-			EnableCPU:    true,
-			EnableMemory: true,
-			EnableDisk:   true,
-			EnableNet:    true,
+			Interval:          5,
+			EnableHost:        true,
+			EnableCPU:         true,
+			EnableMemory:      true,
+			EnableDisk:        true,
+			EnableNet:         true,
+			EnableDiskIO:      true,
+			NetExclude:        []string{"lo"},
+			DiskFstypeExclude: []string{"tmpfs", "devtmpfs", "overlay", "squashfs"},
+			DiskIOExclude:     []string{"loop*", "ram*"},
+			
+			DiskForecastWindow:   6 * 3600,
+			EnableProcesses:      true,
+			ProcessTopN:          10,
+			ProcessSortBy:        []string{"cpu", "memory"},
+			ProcessRedactCmdline: true,
+		},
+		History: HistoryConfig{
+			Enabled:   true,
+			Retention: 3600,
+		},
+		Storage: StorageConfig{
+			Enabled:       false,
+			DataDir:       "data",
+			Retention:     7 * 24 * 3600,
+			MaxDiskMB:     512,
+			BlockDuration: 2 * 3600,
+			Rollups: []RollupConfig{
+				{Resolution: 60, Retention: 30 * 24 * 3600},
+				{Resolution: 600, Retention: 90 * 24 * 3600},
+				{Resolution: 3600, Retention: 365 * 24 * 3600},
+			},
+		},
+		Alerts: AlertsConfig{
+			Enabled: true,
+			Rules: []AlertRuleConfig{
+				{Name: "high_cpu", Metric: "cpu.usage", Op: ">", Threshold: 90, Clear: floatPtr(80), For: 300, Severity: "warning"},
+				{Name: "high_memory", Metric: "memory.usage", Op: ">", Threshold: 90, Clear: floatPtr(85), For: 300, Severity: "warning"},
+				{Name: "disk_full", Metric: "disk.filesystems.*.usage", Op: ">", Threshold: 90, Clear: floatPtr(85), For: 60, Severity: "critical"},
+				// Tahmini dolma süresi 4 saatin altına inen dosya sistemleri
+				{Name: "disk_filling", Metric: "disk.filesystems.*.time_to_full_seconds", Op: "<", Threshold: 4 * 3600, Clear: floatPtr(8 * 3600), For: 600, Severity: "warning", Summary: "Disk 4 saatten kısa sürede dolacak"},
+			},
+			SilencesFile: "data/silences.json",
 		},
 	}
 }
 
-// Load konfigürasyon dosyasından ayarları yükler
+// floatPtr sabit değerden pointer oluşturur (opsiyonel alanlar için)
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// Load konfigürasyon dosyasından ayarları yükler. Format dosya uzantısından
+// belirlenir (.json, .yaml/.yml, .toml). Ana dosyada include verilmişse dizindeki
+// parçalar isim sırasıyla birleştirilir: nesneler alan alan birleştirilir, listeler
+// değiştirilir veya "+" sonekli anahtarla ("rules+") sona eklenir. En son
+// SYSWATCH_ ile başlayan ortam değişkenleri uygulanır. Her değerin kaynağı
+// Origin ile sorgulanabilir.
+//
+// Bilinmeyen alanlar (ör. "intervall") ve tipi uyuşmayan değerler yok sayılmaz;
+// tüm dosyalardaki bu hatalar yolları ve dosyalarıyla birlikte *ValidationError
+// olarak döner.
 func Load(configPath string) (*Config, error) {
+	config, fileErrs, err := load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileErrs) > 0 {
+		return nil, &ValidationError{Issues: fileErrs}
+	}
+	return config, nil
+}
+
+// load config'i yükler; bilinmeyen alanlar ve tipi uyuşmayan değerler atlanarak
+// ayrıca döndürülür
+func load(configPath string) (*Config, []Issue, error) {
 	log := logger.GetLogger()
 	
 	// Varsayılan konfigürasyon ile başla
-	config := Default()
+	l, err := newLoader()
+	if err != nil {
+		return nil, nil, err
+	}
 	
 	// Dosya var mı kontrol et
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		log.Infof("Konfigürasyon dosyası bulunamadı: %s, varsayılan ayarlar kullanılıyor", configPath)
-		return config, nil
+	} else {
+		if err := l.mergeFile(configPath, false); err != nil {
+			return nil, nil, err
+		}
+		log.Infof("Konfigürasyon dosyası başarıyla yüklendi: %s", configPath)
+		
+		// Config parçalarını birleştir
+		if include := l.include(); include != "" {
+			dir := IncludeDir(configPath, include)
+			files, err := fragmentFiles(dir)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, file := range files {
+				if err := l.mergeFile(file, true); err != nil {
+					return nil, nil, err
+				}
+			}
+			log.Infof("%d konfigürasyon parçası yüklendi: %s", len(files), dir)
+		}
 	}
 	
-	// Dosyayı oku
-	data, err := os.ReadFile(configPath)
+	config, err := l.decode()
 	if err != nil {
-		return nil, fmt.Errorf("konfigürasyon dosyası okunamadı: %w", err)
+		return nil, nil, fmt.Errorf("konfigürasyon dosyası parse edilemedi: %w", err)
 	}
 	
-	// JSON'dan parse et
-	if err := json.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("konfigürasyon dosyası parse edilemedi: %w", err)
+	// Ortam değişkenleri dosyadaki değerleri ezer
+	unknownEnv, err := config.ApplyEnv(os.Environ())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range unknownEnv {
+		config.warnings = append(config.warnings, Issue{Message: "hiçbir config alanına karşılık gelmiyor, yok sayıldı", Origin: "env:" + name})
+	}
+	for _, w := range config.warnings {
+		log.Warnf("Konfigürasyon uyarısı: %s", w)
 	}
 	
-	log.Infof("Konfigürasyon dosyası başarıyla yüklendi: %s", configPath)
-	return config, nil
+	return config, l.errs, nil
 }
 
-// Save konfigürasyonu dosyaya kaydeder
+// IncludeDir include dizinini ana config dosyasının dizinine göre çözer
+func IncludeDir(configPath, include string) string {
+	if filepath.IsAbs(include) {
+		return include
+	}
+	return filepath.Join(filepath.Dir(configPath), include)
+}
+
+// Save konfigürasyonu dosya uzantısına uygun formatta kaydeder
 func (c *Config) Save(configPath string) error {
 	log := logger.GetLogger()
 	
@@ -119,8 +408,8 @@ func (c *Config) Save(configPath string) error {
 		return fmt.Errorf("konfigürasyon dizini oluşturulamadı: %w", err)
 	}
 	
-	// JSON formatında serialize et
-	data, err := json.MarshalIndent(c, "", "  ")
+	// Uzantıya uygun formatta serialize et
+	data, err := c.Marshal(FormatFromPath(configPath))
 	if err != nil {
 		return fmt.Errorf("konfigürasyon serialize edilemedi: %w", err)
 	}
@@ -133,31 +422,3 @@ func (c *Config) Save(configPath string) error {
 	log.Infof("Konfigürasyon dosyası başarıyla kaydedildi: %s", configPath)
 	return nil
 }
-
-// Validate konfigürasyonu doğrular
-func (c *Config) Validate() error {
-	// Dashboard port kontrolü
-	if c.Dashboard.Port < 1024 || c.Dashboard.Port > 65535 {
-		return fmt.Errorf("dashboard port geçersiz: %d (1024-65535 arası olmalı)", c.Dashboard.Port)
-	}
-	
-	// Logging level kontrolü
-	validLevels := []string{"debug", "info", "warn", "error"}
-	isValid := false
-	for _, level := range validLevels {
-		if c.Logging.Level == level {
-			isValid = true
-			break
-		}
-	}
-	if !isValid {
-		return fmt.Errorf("geçersiz log level: %s (debug, info, warn, error olmalı)", c.Logging.Level)
-	}
-	
-	// Metrics interval kontrolü
-	if c.Metrics.Interval < 1 || c.Metrics.Interval > 3600 {
-		return fmt.Errorf("metrics interval geçersiz: %d (1-3600 saniye arası olmalı)", c.Metrics.Interval)
-	}
-	
-	return nil
-}
\ No newline at end of file
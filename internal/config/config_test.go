@@ -83,6 +83,31 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoadAlertRules(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "alerts.json")
+	data := `{"alerts": {"enabled": true, "rules": [{"name": "mem", "metric": "memory.usage", "op": "<", "threshold": 5}]}}`
+	if err := os.WriteFile(configPath, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	
+	// Dosyadaki liste varsayılan kuralların yerine geçmeli; clear gibi alanlar varsayılandan kalmamalı
+	if len(cfg.Alerts.Rules) != 1 {
+		t.Fatalf("Expected 1 alert rule, got %d", len(cfg.Alerts.Rules))
+	}
+	rule := cfg.Alerts.Rules[0]
+	if rule.Clear != nil || rule.For != 0 || rule.Severity != "" {
+		t.Errorf("Expected unset fields to stay empty, got %+v", rule)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected loaded config to be valid, got: %v", err)
+	}
+}
+
 func TestLoadNonExistentFile(t *testing.T) {
 	// Var olmayan dosya yükle - varsayılan konfigürasyon dönmeli
 	cfg, err := Load("nonexistent.json")
@@ -180,6 +205,102 @@ func TestValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "invalid watchlist - no matcher",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info"},
+				Metrics: MetricsConfig{
+					Interval:  5,
+					Watchlist: []ProcessWatchConfig{{Name: "nginx"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid watchlist - bad cmdline regex",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info"},
+				Metrics: MetricsConfig{
+					Interval:  5,
+					Watchlist: []ProcessWatchConfig{{Name: "app", Cmdline: "app(["}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "valid watchlist",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info"},
+				Metrics: MetricsConfig{
+					Interval: 5,
+					Watchlist: []ProcessWatchConfig{
+						{Name: "nginx", ProcessName: "nginx"},
+						{Name: "postgres", Pidfile: "/var/run/postgresql/postmaster.pid"},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid alert - unknown operator",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info"},
+				Metrics:   MetricsConfig{Interval: 5},
+				Alerts: AlertsConfig{Rules: []AlertRuleConfig{
+					{Name: "cpu", Metric: "cpu.usage", Op: "=>", Threshold: 90},
+				}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid alert - clear above threshold",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info"},
+				Metrics:   MetricsConfig{Interval: 5},
+				Alerts: AlertsConfig{Rules: []AlertRuleConfig{
+					{Name: "cpu", Metric: "cpu.usage", Op: ">", Threshold: 90, Clear: floatPtr(95)},
+				}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid notify channel - bad state",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info"},
+				Metrics:   MetricsConfig{Interval: 5},
+				Alerts: AlertsConfig{Channels: []NotifyChannelConfig{
+					{Name: "ops", Type: "webhook", States: []string{"active"}},
+				}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid logging - file output without filename",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info", Format: "json", Output: "file"},
+				Metrics:   MetricsConfig{Interval: 5},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid maintenance window - bad schedule",
+			config: &Config{
+				Dashboard: DashboardConfig{Port: 8080},
+				Logging:   LoggingConfig{Level: "info"},
+				Metrics:   MetricsConfig{Interval: 5},
+				Alerts: AlertsConfig{Maintenance: []MaintenanceWindowConfig{
+					{Name: "patch", Schedule: "0 25 * * *", Duration: 3600},
+				}},
+			},
+			expectErr: true,
+		},
 	}
 	
 	for _, tc := range testCases {
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix config değerlerini ezen ortam değişkenlerinin ön eki.
+// Değişken ismi json alan yolunun büyük harfli ve _ ile birleştirilmiş halidir:
+//
+//	SYSWATCH_DASHBOARD_PORT=9090
+//	SYSWATCH_METRICS_DISK_FORECAST_WINDOW=3600
+//	SYSWATCH_ALERTS_RULES_0_THRESHOLD=95
+//	SYSWATCH_METRICS_NET_EXCLUDE=lo,veth*
+//	SYSWATCH_ALERTS_CHANNELS_0_OPTIONS_PASSWORD=secret
+//
+// Listeler virgülle ayrılmış değer veya JSON, nesneler JSON olarak verilir.
+// Map anahtarları küçük harfe çevrilir; serbest seçeneklerin (options) değerleri
+// JSON olarak çözülebiliyorsa sayı, bool veya liste olarak atanır.
+const EnvPrefix = "SYSWATCH_"
+
+// errEnvPathNotFound ortam değişkeni bir config alanına karşılık gelmiyor
+var errEnvPathNotFound = errors.New("config alanı bulunamadı")
+
+// ApplyEnv SYSWATCH_ ile başlayan ortam değişkenlerini config'e uygular.
+// environ os.Environ() biçimindedir (KEY=değer). Hiçbir alana karşılık gelmeyen
+// değişkenler atlanır ve isimleri döndürülür; tip dönüşüm hataları hata olarak döner.
+func (c *Config) ApplyEnv(environ []string) (unknown []string, err error) {
+	vars := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, EnvPrefix) {
+			vars = append(vars, kv)
+		}
+	}
+	// Aynı alana karşılık gelen değişkenlerde sonuç deterministik olsun
+	sort.Strings(vars)
+
+	for _, kv := range vars {
+		name, value, _ := strings.Cut(kv, "=")
+		path := strings.TrimPrefix(name, EnvPrefix)
+		if path == "" {
+			continue
+		}
+
+		field, err := setEnvPath(reflect.ValueOf(c).Elem(), path, value)
+		if err == errEnvPathNotFound {
+			unknown = append(unknown, name)
+			continue
+		}
+		if err != nil {
+			return unknown, fmt.Errorf("ortam değişkeni %s: %w", name, err)
+		}
+		c.SetOrigin(field, "env:"+name)
+	}
+	return unknown, nil
+}
+
+// setEnvPath büyük harfli ve _ ile ayrılmış yolu v içinde izleyip değeri atar;
+// atanan alanın json yolunu döndürür (alerts.rules[0].threshold)
+func setEnvPath(v reflect.Value, path, value string) (string, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			return "", errEnvPathNotFound
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setEnvPath(v.Elem(), path, value)
+
+	case reflect.Struct:
+		t := v.Type()
+		// Tam eşleşme önce denenir; ENABLE_CPU ile ENABLE_CPU_PER_CORE gibi
+		// önek ilişkisi olan alanlar için en uzun isim önceliklidir
+		var prefixed []int
+		for i := 0; i < t.NumField(); i++ {
+			key := envKey(t.Field(i))
+			if key == "" {
+				continue
+			}
+			if path == key {
+				return jsonName(t.Field(i)), setEnvValue(v.Field(i), value)
+			}
+			if strings.HasPrefix(path, key+"_") {
+				prefixed = append(prefixed, i)
+			}
+		}
+		sort.Slice(prefixed, func(a, b int) bool {
+			return len(envKey(t.Field(prefixed[a]))) > len(envKey(t.Field(prefixed[b])))
+		})
+		for _, i := range prefixed {
+			rest := strings.TrimPrefix(path, envKey(t.Field(i))+"_")
+			field, err := setEnvPath(v.Field(i), rest, value)
+			if err != errEnvPathNotFound {
+				return joinPath(jsonName(t.Field(i)), field), err
+			}
+		}
+		return "", errEnvPathNotFound
+
+	case reflect.Slice:
+		index, rest, _ := strings.Cut(path, "_")
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			return "", errEnvPathNotFound
+		}
+		if i < 0 || i >= v.Len() {
+			return "", fmt.Errorf("liste indeksi aralık dışında: %d (%d eleman)", i, v.Len())
+		}
+		if rest == "" {
+			return indexPath("", i), setEnvValue(v.Index(i), value)
+		}
+		field, err := setEnvPath(v.Index(i), rest, value)
+		return indexPath("", i) + "." + field, err
+
+	case reflect.Map:
+		// Seçenek ve etiket anahtarları küçük harfli kabul edilir (OPTIONS_PASSWORD -> password)
+		if v.Type().Key().Kind() != reflect.String {
+			return "", errEnvPathNotFound
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if elem.Kind() == reflect.Interface {
+			// Serbest seçeneklerde tip bilinmez: değer önce JSON olarak çözülür
+			// (587, true, ["a","b"]), çözülemezse metin kabul edilir. Sayı gibi
+			// görünen metinler tırnakla verilebilir ("\"0123\"").
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+				elem.Set(reflect.ValueOf(&decoded).Elem())
+			} else {
+				elem.Set(reflect.ValueOf(value))
+			}
+		} else if err := setEnvValue(elem, value); err != nil {
+			return "", err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := strings.ToLower(path)
+		v.SetMapIndex(reflect.ValueOf(key), elem)
+		return key, nil
+	}
+	return "", errEnvPathNotFound
+}
+
+// setEnvValue metin değeri alanın tipine dönüştürüp atar
+func setEnvValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+		return nil
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q bool değil (true/false olmalı)", value)
+		}
+		v.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q tam sayı değil", value)
+		}
+		v.SetInt(n)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q sayı değil", value)
+		}
+		v.SetFloat(f)
+		return nil
+
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := setEnvValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+
+	case reflect.Slice:
+		// Metin listeleri virgülle ayrılmış olarak da verilebilir
+		if v.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(strings.TrimSpace(value), "[") {
+			items := reflect.MakeSlice(v.Type(), 0, 0)
+			if value != "" {
+				for _, item := range strings.Split(value, ",") {
+					items = reflect.Append(items, reflect.ValueOf(strings.TrimSpace(item)))
+				}
+			}
+			v.Set(items)
+			return nil
+		}
+	}
+
+	// Listeler, map'ler ve nesneler JSON olarak verilir; dosyalarda olduğu gibi bilinmeyen alanlar reddedilir
+	target := reflect.New(v.Type())
+	dec := json.NewDecoder(strings.NewReader(value))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(target.Interface()); err != nil {
+		return fmt.Errorf("%q %s olarak çözülemedi (JSON bekleniyor): %v", value, v.Type(), err)
+	}
+	v.Set(target.Elem())
+	return nil
+}
+
+// envKey alanın ortam değişkenindeki ismini döndürür (json etiketinin büyük harflisi)
+func envKey(f reflect.StructField) string {
+	return strings.ToUpper(jsonName(f))
+}
+
+// jsonName alanın json ismini döndürür; json'a yazılmayan alanlar için boş döner
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name
+}
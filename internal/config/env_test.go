@@ -0,0 +1,91 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyEnv(t *testing.T) {
+	cfg := Default()
+	unknown, err := cfg.ApplyEnv([]string{
+		"PATH=/usr/bin",
+		"SYSWATCH_DASHBOARD_PORT=9090",
+		"SYSWATCH_DASHBOARD_ENABLED=false",
+		"SYSWATCH_METRICS_DISK_FORECAST_WINDOW=3600",
+		"SYSWATCH_METRICS_NET_EXCLUDE=lo, veth*",
+		"SYSWATCH_METRICS_PROCESS_SORT_BY=[\"memory\"]",
+		"SYSWATCH_ALERTS_RULES_0_THRESHOLD=95.5",
+		"SYSWATCH_ALERTS_RULES_0_CLEAR=80",
+		"SYSWATCH_ALERTS_RULES_0_LABELS_TEAM=ops",
+		"SYSWATCH_UNKNOWN_SETTING=1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Dashboard.Port != 9090 || cfg.Dashboard.Enabled {
+		t.Errorf("Expected dashboard port 9090 and disabled, got %+v", cfg.Dashboard)
+	}
+	if cfg.Metrics.DiskForecastWindow != 3600 {
+		t.Errorf("Expected disk_forecast_window 3600, got %d", cfg.Metrics.DiskForecastWindow)
+	}
+	if strings.Join(cfg.Metrics.NetExclude, ",") != "lo,veth*" {
+		t.Errorf("Expected comma separated list, got %v", cfg.Metrics.NetExclude)
+	}
+	if strings.Join(cfg.Metrics.ProcessSortBy, ",") != "memory" {
+		t.Errorf("Expected JSON list, got %v", cfg.Metrics.ProcessSortBy)
+	}
+	rule := cfg.Alerts.Rules[0]
+	if rule.Threshold != 95.5 || rule.Clear == nil || *rule.Clear != 80 || rule.Labels["team"] != "ops" {
+		t.Errorf("Expected rule overrides to be applied, got %+v", rule)
+	}
+	if len(unknown) != 1 || unknown[0] != "SYSWATCH_UNKNOWN_SETTING" {
+		t.Errorf("Expected unknown variable to be reported, got %v", unknown)
+	}
+}
+
+func TestApplyEnvOptions(t *testing.T) {
+	cfg := Default()
+	cfg.Alerts.Channels = []NotifyChannelConfig{{Name: "mail", Type: "smtp"}}
+	_, err := cfg.ApplyEnv([]string{
+		"SYSWATCH_ALERTS_CHANNELS_0_OPTIONS_PORT=587",
+		"SYSWATCH_ALERTS_CHANNELS_0_OPTIONS_INSECURE_SKIP_VERIFY=true",
+		"SYSWATCH_ALERTS_CHANNELS_0_OPTIONS_HOST=smtp.example.com",
+		`SYSWATCH_ALERTS_CHANNELS_0_OPTIONS_PASSWORD="0123"`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Seçenekler kanalın kendi tipine katı şekilde çözülebilmeli
+	var opts struct {
+		Host     string `json:"host"`
+		Port     int    `json:"port"`
+		Insecure bool   `json:"insecure_skip_verify"`
+		Password string `json:"password"`
+	}
+	if err := DecodeOptions(cfg.Alerts.Channels[0].Options, &opts); err != nil {
+		t.Fatalf("DecodeOptions() failed: %v", err)
+	}
+	if opts.Port != 587 || !opts.Insecure || opts.Host != "smtp.example.com" || opts.Password != "0123" {
+		t.Errorf("Unexpected options from environment: %+v", opts)
+	}
+}
+
+func TestApplyEnvErrors(t *testing.T) {
+	tests := []struct {
+		env  string
+		want string
+	}{
+		{"SYSWATCH_DASHBOARD_PORT=abc", "SYSWATCH_DASHBOARD_PORT"},
+		{"SYSWATCH_METRICS_ENABLE_CPU=maybe", "bool"},
+		{"SYSWATCH_ALERTS_RULES_99_THRESHOLD=1", "aralık dışında"},
+		{"SYSWATCH_METRICS_WATCHLIST={bad", "JSON"},
+	}
+	for _, tt := range tests {
+		_, err := Default().ApplyEnv([]string{tt.env})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.env, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Desteklenen config dosyası formatları
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// FormatFromPath dosya uzantısına göre config formatını döndürür.
+// Bilinmeyen uzantılar geriye uyumluluk için JSON kabul edilir.
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	}
+	return FormatJSON
+}
+
+// decodeRaw veriyi formatına göre genel bir yapıya çözer. JSON sayıları
+// json.Number olarak tutulur ki tam sayılar yeniden serialize edilirken bozulmasın.
+func decodeRaw(data []byte, format string) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("desteklenmeyen config formatı: %s", format)
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+	return raw, nil
+}
+
+// Marshal config'i verilen formatta (json, yaml, toml) serialize eder
+func (c *Config) Marshal(format string) ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil || format == FormatJSON {
+		return data, err
+	}
+
+	// Alan isimleri ve sırası json etiketlerinden gelsin diye JSON üzerinden çevrilir
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	normalized := normalize(raw)
+
+	switch format {
+	case FormatYAML:
+		return yaml.Marshal(normalized)
+	case FormatTOML:
+		return toml.Marshal(normalized)
+	}
+	return nil, fmt.Errorf("desteklenmeyen config formatı: %s", format)
+}
+
+// normalize JSON sayılarını tam sayı veya ondalık değere çevirir ve null
+// değerleri atar (TOML'da null karşılığı yoktur)
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if item == nil {
+				delete(v, k)
+				continue
+			}
+			v[k] = normalize(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return v
+}
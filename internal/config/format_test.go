@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	tests := map[string]string{
+		"config.json":       FormatJSON,
+		"config.YAML":       FormatYAML,
+		"/etc/syswatch.yml": FormatYAML,
+		"config.toml":       FormatTOML,
+		"config":            FormatJSON,
+	}
+	for path, want := range tests {
+		if got := FormatFromPath(path); got != want {
+			t.Errorf("FormatFromPath(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestSaveLoadFormats(t *testing.T) {
+	want := Default()
+	want.Dashboard.Port = 9191
+	want.Metrics.NetExclude = []string{"lo", "veth*"}
+	want.Alerts.Channels = []NotifyChannelConfig{{
+		Name:    "ops",
+		Type:    "webhook",
+		Options: map[string]interface{}{"url": "http://example.com/hook"},
+	}}
+
+	for _, name := range []string{"config.yaml", "config.toml"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := want.Save(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, err := Load(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		// Kaynak bilgisi karşılaştırmaya dahil edilmez
+		got.origins, got.files = nil, nil
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: round trip mismatch\ngot:  %+v\nwant: %+v", name, got, want)
+		}
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	data := `
+dashboard:
+  port: 9092
+metrics:
+  interval: 10
+  net_exclude: [lo]
+alerts:
+  rules:
+    - name: high_cpu
+      metric: cpu.usage
+      op: ">"
+      threshold: 90.5
+      severity: critical
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Dashboard.Port != 9092 || cfg.Metrics.Interval != 10 {
+		t.Errorf("Expected port 9092 and interval 10, got %d and %d", cfg.Dashboard.Port, cfg.Metrics.Interval)
+	}
+	// Dosyada verilmeyen alanlar varsayılan kalmalı
+	if !cfg.Metrics.EnableCPU {
+		t.Error("Expected defaults to be kept for fields missing in YAML")
+	}
+	if len(cfg.Alerts.Rules) != 1 || cfg.Alerts.Rules[0].Threshold != 90.5 {
+		t.Errorf("Expected one rule with threshold 90.5, got %+v", cfg.Alerts.Rules)
+	}
+
+	if err := os.WriteFile(path, []byte("dashboard: [unclosed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected parse error for invalid YAML")
+	}
+}
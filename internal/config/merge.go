@@ -0,0 +1,282 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// appendSuffix liste anahtarının sonuna eklendiğinde elemanlar mevcut listeye eklenir
+// ("rules+": [...]); sonek yoksa liste tamamen değiştirilir.
+const appendSuffix = "+"
+
+// OriginDefault dosya veya ortam değişkeninden gelmeyen değerlerin kaynağı
+const OriginDefault = "default"
+
+// loader varsayılan config üzerine ana dosyayı ve parçaları sırayla birleştirir,
+// her değerin hangi kaynaktan geldiğini kaydeder
+type loader struct {
+	raw      map[string]interface{}
+	origins  map[string]string
+	files    []string
+	errs     []Issue // Dosyalardaki bilinmeyen alanlar ve tip uyuşmazlıkları
+	warnings []Issue // Kullanımdan kalkan alanlar
+}
+
+// newLoader varsayılan config ile başlayan loader oluşturur
+func newLoader() (*loader, error) {
+	data, err := json.Marshal(Default())
+	if err != nil {
+		return nil, err
+	}
+	raw, err := decodeRaw(data, FormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &loader{raw: raw, origins: make(map[string]string)}, nil
+}
+
+// mergeFile dosyayı okuyup birleştirir. fragment true ise include kullanılamaz.
+// Bilinmeyen alanlar ve tipi uyuşmayan değerler l.errs'e kaydedilip atlanır;
+// hata sadece dosya okunamadığında veya parse edilemediğinde döner.
+func (l *loader) mergeFile(path string, fragment bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("konfigürasyon dosyası okunamadı: %w", err)
+	}
+
+	format := FormatFromPath(path)
+	src, err := decodeRaw(data, format)
+	if err != nil {
+		return fmt.Errorf("konfigürasyon dosyası parse edilemedi (%s, %s): %w", path, format, err)
+	}
+	if fragment {
+		if _, ok := src["include"]; ok {
+			l.errs = append(l.errs, Issue{Path: "include", Message: "sadece ana konfigürasyon dosyasında kullanılabilir", Origin: path})
+			delete(src, "include")
+		}
+	}
+	l.checkKeys(src, configType, "", path)
+	l.merge(l.raw, src, "", path)
+	l.files = append(l.files, path)
+
+	// checkKeys'in yakalayamadığı bir uyuşmazlık kalırsa bu dosyaya ait olsun diye
+	// birleştirilmiş hal hemen çözülür
+	if _, err := l.decode(); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("%s: %s: %s değeri %s tipine çözülemedi", path, typeErr.Field, typeErr.Value, typeErr.Type)
+		}
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// merge src'yi dst'ye derinlemesine birleştirir. Nesneler alan alan birleştirilir,
+// listeler değiştirilir veya "+" sonekli anahtarlarla sona eklenir. Mevcut
+// değerle tipi uyuşmayan değerler (ör. serbest options alanlarında) hata olarak
+// kaydedilip atlanır.
+func (l *loader) merge(dst, src map[string]interface{}, prefix, origin string) {
+	// Aynı dosyada hem "rules" hem "rules+" varsa sonuç sıradan bağımsız olsun
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := src[key]
+		name, appending := strings.CutSuffix(key, appendSuffix)
+		path := joinPath(prefix, name)
+
+		if appending {
+			items, ok := value.([]interface{})
+			if !ok {
+				l.mismatch(joinPath(prefix, key), "liste bekleniyor, %s verildi", origin, kindOf(value))
+				continue
+			}
+			existing, ok := dst[name].([]interface{})
+			if !ok && dst[name] != nil {
+				l.mismatch(joinPath(prefix, key), "%s üzerine liste eklenemez", origin, kindOf(dst[name]))
+				continue
+			}
+			for i := range items {
+				l.record(indexPath(path, len(existing)+i), origin)
+			}
+			dst[name] = append(existing, items...)
+			continue
+		}
+
+		if srcMap, ok := value.(map[string]interface{}); ok {
+			dstMap, ok := dst[name].(map[string]interface{})
+			if !ok {
+				if dst[name] != nil {
+					l.mismatch(path, "%s bekleniyor, nesne verildi", origin, kindOf(dst[name]))
+					continue
+				}
+				dstMap = make(map[string]interface{})
+				dst[name] = dstMap
+			}
+			l.merge(dstMap, srcMap, path, origin)
+			continue
+		}
+
+		if existing, ok := dst[name]; ok && existing != nil && value != nil {
+			if want, got := kindOf(existing), kindOf(value); want != got {
+				l.mismatch(path, "%s bekleniyor, %s verildi", origin, want, got)
+				continue
+			}
+		}
+		dst[name] = value
+		l.record(path, origin)
+	}
+}
+
+// mismatch birleştirme sırasında bulunan tip uyuşmazlığını kaydeder
+func (l *loader) mismatch(path, format, origin string, args ...interface{}) {
+	l.errs = append(l.errs, Issue{Path: path, Message: fmt.Sprintf(format, args...), Origin: origin})
+}
+
+// record yolun kaynağını kaydeder; yolun altındaki eski kayıtlar silinir
+func (l *loader) record(path, origin string) {
+	for p := range l.origins {
+		if strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			delete(l.origins, p)
+		}
+	}
+	l.origins[path] = origin
+}
+
+// include ana dosyadaki include dizinini döndürür
+func (l *loader) include() string {
+	s, _ := l.raw["include"].(string)
+	return s
+}
+
+// decode birleştirilmiş değerleri config'e çözer. Bilinmeyen alanlar
+// checkKeys ile ayıklandığından kalan her alan config'de karşılık bulmalıdır.
+func (l *loader) decode() (*Config, error) {
+	data, err := json.Marshal(l.raw)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(c); err != nil {
+		return nil, err
+	}
+	c.origins = l.origins
+	c.files = l.files
+	c.warnings = append([]Issue(nil), l.warnings...)
+	return c, nil
+}
+
+// fragmentFiles include dizinindeki config parçalarını isim sırasıyla döndürür.
+// Gizli dosyalar ve desteklenmeyen uzantılar (ör. .bak) atlanır.
+func fragmentFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("konfigürasyon parça dizini okunamadı: %w", err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".json", ".yaml", ".yml", ".toml":
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// kindOf değerin tipini hata mesajları için döndürür
+func kindOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "nesne"
+	case []interface{}:
+		return "liste"
+	case string:
+		return "metin"
+	case bool:
+		return "bool"
+	case json.Number, int, int64, uint64, float64:
+		return "sayı"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// joinPath json alan yoluna yeni bir anahtar ekler
+func joinPath(prefix, key string) string {
+	if prefix == "" || strings.HasPrefix(key, "[") {
+		return prefix + key
+	}
+	return prefix + "." + key
+}
+
+// Origin verilen json yolundaki değerin kaynağını döndürür (dosya yolu,
+// "env:SYSWATCH_..." veya "default"). Yol en yakın kayıtlı üst yola göre çözülür:
+// alerts.rules[1].threshold, alerts.rules listesi bir dosyadan geliyorsa o dosyayı döndürür.
+func (c *Config) Origin(path string) string {
+	for p := path; p != ""; p = parentPath(p) {
+		if origin, ok := c.origins[p]; ok {
+			return origin
+		}
+	}
+	return OriginDefault
+}
+
+// Origins varsayılandan farklı kaynaktan gelen değerlerin yollarını ve kaynaklarını döndürür
+func (c *Config) Origins() map[string]string {
+	result := make(map[string]string, len(c.origins))
+	for p, origin := range c.origins {
+		result[p] = origin
+	}
+	return result
+}
+
+// SetOrigin yolun kaynağını kaydeder (ör. komut satırı parametresiyle ezilen değerler için)
+func (c *Config) SetOrigin(path, origin string) {
+	if c.origins == nil {
+		c.origins = make(map[string]string)
+	}
+	l := loader{origins: c.origins}
+	l.record(path, origin)
+}
+
+// Files config'in okunduğu dosyaları (ana dosya ve parçalar) okunma sırasıyla döndürür
+func (c *Config) Files() []string {
+	return append([]string(nil), c.files...)
+}
+
+// parentPath yolun bir üst yolunu döndürür (a.b[2] -> a.b, a.b -> a)
+func parentPath(path string) string {
+	if strings.HasSuffix(path, "]") {
+		if i := strings.LastIndex(path, "["); i >= 0 {
+			return path[:i]
+		}
+	}
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// indexPath liste elemanının yolunu oluşturur
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
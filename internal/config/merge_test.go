@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles verilen dosyaları dizine yazar
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadFragments(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.json": `{
+			"include": "conf.d",
+			"dashboard": {"port": 9000},
+			"alerts": {"rules": [{"name": "high_cpu", "metric": "cpu.usage", "op": ">", "threshold": 90}]}
+		}`,
+		// İsim sırasıyla uygulanmalı: 20 numaralı parça 10'u ezer
+		"conf.d/10-web.yaml": "dashboard:\n  port: 9001\n  host: 0.0.0.0\n",
+		"conf.d/20-web.toml": "[dashboard]\nport = 9002\n",
+		"conf.d/30-rules.yaml": `
+alerts:
+  rules+:
+    - name: disk_full
+      metric: disk.usage
+      op: ">"
+      threshold: 95
+metrics:
+  net_exclude: [lo]
+`,
+		"conf.d/README.md":       "not a config",
+		"conf.d/.40-hidden.json": `{"dashboard": {"port": 1}}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Dashboard.Port != 9002 || cfg.Dashboard.Host != "0.0.0.0" {
+		t.Errorf("Expected fragments to be merged in lexical order, got %+v", cfg.Dashboard)
+	}
+	if len(cfg.Alerts.Rules) != 2 || cfg.Alerts.Rules[0].Name != "high_cpu" || cfg.Alerts.Rules[1].Name != "disk_full" {
+		t.Errorf("Expected appended rule after main file rule, got %+v", cfg.Alerts.Rules)
+	}
+	// Dosyalarda verilmeyen alanlar varsayılan kalmalı
+	if cfg.Metrics.Interval != 5 || !cfg.Metrics.EnableCPU {
+		t.Error("Expected defaults to be kept")
+	}
+
+	origins := map[string]string{
+		"dashboard.port":            "20-web.toml",
+		"dashboard.host":            "10-web.yaml",
+		"alerts.rules[0].threshold": "config.json",
+		"alerts.rules[1].threshold": "30-rules.yaml",
+		"metrics.net_exclude":       "30-rules.yaml",
+		"metrics.interval":          OriginDefault,
+	}
+	for path, want := range origins {
+		if got := cfg.Origin(path); filepath.Base(got) != want {
+			t.Errorf("Origin(%s) = %s, want %s", path, got, want)
+		}
+	}
+	if files := cfg.Files(); len(files) != 4 {
+		t.Errorf("Expected main file and 3 fragments to be read, got %v", files)
+	}
+}
+
+func TestLoadFragmentErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		want     []string // Hata mesajında bulunması gerekenler
+	}{
+		{"type.yaml", "dashboard:\n  port: [1]\n", []string{"type.yaml", "dashboard.port", "sayı bekleniyor"}},
+		{"object.json", `{"metrics": {"net_exclude": {"a": 1}}}`, []string{"object.json", "metrics.net_exclude"}},
+		{"append.json", `{"alerts": {"rules+": {"name": "x"}}}`, []string{"append.json", "alerts.rules+", "liste bekleniyor"}},
+		{"rule.yaml", "alerts:\n  rules+:\n    - name: x\n      threshold: high\n", []string{"rule.yaml", "threshold"}},
+		{"include.json", `{"include": "other"}`, []string{"include.json", "include"}},
+		{"syntax.toml", "[dashboard\n", []string{"syntax.toml"}},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"config.json":       `{"include": "conf.d"}`,
+			"conf.d/" + tt.name: tt.fragment,
+		})
+		_, err := Load(filepath.Join(dir, "config.json"))
+		if err == nil {
+			t.Errorf("%s: expected error", tt.name)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: expected error to contain %q, got %v", tt.name, want, err)
+			}
+		}
+	}
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"config.json": `{"include": "missing.d"}`})
+	if _, err := Load(filepath.Join(dir, "config.json")); err == nil {
+		t.Error("Expected error for missing include directory")
+	}
+}
+
+func TestOriginEnv(t *testing.T) {
+	cfg := Default()
+	if _, err := cfg.ApplyEnv([]string{"SYSWATCH_ALERTS_RULES_0_THRESHOLD=99"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Origin("alerts.rules[0].threshold"); got != "env:SYSWATCH_ALERTS_RULES_0_THRESHOLD" {
+		t.Errorf("Expected env origin, got %s", got)
+	}
+	if got := cfg.Origin("alerts.rules[0].for"); got != OriginDefault {
+		t.Errorf("Expected default origin for untouched field, got %s", got)
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// Kaynak ve kanal tipleri metrics ve notify paketlerinin Register fonksiyonlarıyla
+// burada da kaydedilir; config bu paketleri import edemediğinden doğrulama
+// kayıtlı tipleri ve seçenek struct'larını buradan okur.
+var (
+	typesMu      sync.RWMutex
+	sourceTypes  = make(map[string]OptionsFunc)
+	channelTypes = make(map[string]OptionsFunc)
+)
+
+// OptionsFunc tipin seçenek struct'ına yeni bir pointer döndürür (ör. &smtpOptions{}).
+// Doğrulamada options alanı bu struct'a DecodeOptions ile çözülür; böylece
+// bilinmeyen seçenekler kaynak veya kanal başlatılmadan bulunur.
+type OptionsFunc func() interface{}
+
+// RegisterSourceType metrik kaynağı tipini doğrulamada geçerli sayılması için kaydeder.
+// options nil ise kaynağın seçenekleri doğrulamada kontrol edilmez.
+func RegisterSourceType(name string, options OptionsFunc) {
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	sourceTypes[name] = options
+}
+
+// RegisterChannelType bildirim kanalı tipini doğrulamada geçerli sayılması için kaydeder.
+// options nil ise kanalın seçenekleri doğrulamada kontrol edilmez.
+func RegisterChannelType(name string, options OptionsFunc) {
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	channelTypes[name] = options
+}
+
+// checkType tipin kayıtlı olup olmadığını döndürür. Kayıtlıysa seçenek struct'ı
+// (varsa), değilse kayıtlı tipler sıralı olarak döner.
+func checkType(types map[string]OptionsFunc, name string) (bool, OptionsFunc, []string) {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+
+	if options, ok := types[name]; ok {
+		return true, options, nil
+	}
+	names := make([]string, 0, len(types))
+	for n := range types {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return false, nil, names
+}
+
+// DecodeOptions config'deki serbest anahtarlı seçenek map'ini (kaynak ve kanal
+// options alanları) verilen struct'a dönüştürür. Struct'ta olmayan seçenekler
+// yazım hatalarının sessizce yok sayılmaması için hata döndürür.
+func DecodeOptions(options map[string]interface{}, target interface{}) error {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(target)
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// deprecatedKeys kullanımdan kalkan alanlar ve kullanıcıya gösterilecek açıklama.
+// Bu alanlar bilinmeyen alan hatası yerine uyarı verir.
+var deprecatedKeys = map[string]string{
+	"daemon.version": "sürüm bilgisi binary'den alınır, alan kaldırılabilir",
+}
+
+// configType json alan kontrolünde kullanılan kök tip
+var configType = reflect.TypeOf(Config{})
+
+// checkKeys src'deki anahtarları t tipinin json alanlarıyla karşılaştırır.
+// Bilinmeyen alanlar ve tipi uyuşmayan değerler hata olarak kaydedilip src'den
+// silinir, böylece aynı dosyadaki diğer sorunlar da raporlanabilir. Kullanımdan
+// kalkan alanlar uyarı olarak kaydedilir.
+func (l *loader) checkKeys(src map[string]interface{}, t reflect.Type, prefix, origin string) {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); name != "" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name, _ := strings.CutSuffix(key, appendSuffix)
+		path := joinPath(prefix, key)
+
+		if msg, ok := deprecatedKeys[joinPath(prefix, name)]; ok {
+			l.warnings = append(l.warnings, Issue{Path: path, Message: "kullanımdan kalktı: " + msg, Origin: origin})
+		}
+
+		ft, ok := fields[name]
+		if !ok {
+			if _, deprecated := deprecatedKeys[joinPath(prefix, name)]; !deprecated {
+				l.errs = append(l.errs, Issue{Path: path, Message: unknownFieldMessage(name, fields), Origin: origin})
+			}
+			delete(src, key)
+			continue
+		}
+		if !l.checkValue(src[key], ft, path, origin) {
+			delete(src, key)
+		}
+	}
+}
+
+// checkValue değerin t tipine çözülebildiğini kontrol eder; nesnelerin alanları ve
+// liste elemanları da kontrol edilir. Değerin kendisi uyuşmuyorsa hata kaydedilir
+// ve false döner. Listede uyuşmayan eleman varsa liste bütünüyle geçersiz sayılır,
+// çünkü elemanı silmek sonraki elemanların yollarını kaydırır.
+func (l *loader) checkValue(v interface{}, t reflect.Type, path, origin string) bool {
+	if v == nil {
+		return true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	mismatch := func(want string) bool {
+		l.errs = append(l.errs, Issue{Path: path, Message: fmt.Sprintf("%s bekleniyor, %s verildi", want, kindOf(v)), Origin: origin})
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return mismatch("nesne")
+		}
+		l.checkKeys(m, t, path, origin)
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return mismatch("nesne")
+		}
+		// Anahtarlar serbesttir (options, labels); sadece değer tipleri kontrol edilir
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if !l.checkValue(m[key], t.Elem(), joinPath(path, key), origin) {
+				delete(m, key)
+			}
+		}
+	case reflect.Slice:
+		items, ok := v.([]interface{})
+		if !ok {
+			return mismatch("liste")
+		}
+		valid := true
+		for i, item := range items {
+			if !l.checkValue(item, t.Elem(), indexPath(path, i), origin) {
+				valid = false
+			}
+		}
+		return valid
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			return mismatch("metin")
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			return mismatch("bool")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := integer(v)
+		if !ok {
+			return mismatch("tam sayı")
+		}
+		if reflect.New(t).Elem().OverflowInt(n) {
+			return mismatch(fmt.Sprintf("%s aralığında tam sayı", t.Kind()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := integer(v)
+		if !ok || n < 0 {
+			return mismatch("pozitif tam sayı")
+		}
+		if reflect.New(t).Elem().OverflowUint(uint64(n)) {
+			return mismatch(fmt.Sprintf("%s aralığında tam sayı", t.Kind()))
+		}
+	case reflect.Float32, reflect.Float64:
+		if !number(v) {
+			return mismatch("sayı")
+		}
+	}
+	return true
+}
+
+// number json, YAML veya TOML'dan çözülen değerin sayı olup olmadığını döndürür
+func number(v interface{}) bool {
+	switch n := v.(type) {
+	case json.Number:
+		_, err := n.Float64()
+		return err == nil
+	case int, int64, uint64, float64:
+		return true
+	}
+	return false
+}
+
+// integer json, YAML veya TOML'dan çözülen değeri tam sayıya çevirir. Kesirli veya
+// üslü yazılan sayılar (1.5, 1e3) tam sayı alanına çözülemez.
+func integer(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := strconv.ParseInt(string(n), 10, 64)
+		return i, err == nil
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint64:
+		return int64(n), n <= math.MaxInt64
+	case float64:
+		// YAML'da 3.0 gibi yazılan değerler json'a 3 olarak çevrilir
+		return int64(n), n == math.Trunc(n) && math.Abs(n) < 1<<63
+	}
+	return 0, false
+}
+
+// unknownFieldMessage bilinmeyen alan için hata mesajı oluşturur; yazım hatasına
+// benzeyen alanlar için doğru ismi önerir ("intervall" -> "interval")
+func unknownFieldMessage(name string, fields map[string]reflect.Type) string {
+	// Kısa isimlerde her alan benzer görünmesin diye uzaklık isim uzunluğuyla sınırlanır
+	best, bestDist := "", min(2, len(name)/2)+1
+	for field := range fields {
+		if d := editDistance(strings.ToLower(name), field); d < bestDist || (d == bestDist && field < best) {
+			best, bestDist = field, d
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("bilinmeyen alan (%q mı demek istediniz?)", best)
+	}
+	return "bilinmeyen alan"
+}
+
+// editDistance iki metin arasındaki Levenshtein uzaklığını döndürür
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
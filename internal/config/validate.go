@@ -0,0 +1,453 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/karsterr/syswatch-daemon/internal/cron"
+)
+
+// Issue konfigürasyondaki tek bir hata veya uyarı
+type Issue struct {
+	Path    string // json yolu (ör. alerts.rules[2].op); belirli bir alana ait değilse boş
+	Message string
+	Origin  string // Değerin geldiği dosya veya ortam değişkeni; varsayılan değerlerde boş
+}
+
+// String sorunu "yol: mesaj (kaynak)" biçiminde döndürür
+func (i Issue) String() string {
+	switch {
+	case i.Path != "" && i.Origin != "":
+		return fmt.Sprintf("%s: %s (%s)", i.Path, i.Message, i.Origin)
+	case i.Path != "":
+		return i.Path + ": " + i.Message
+	case i.Origin != "":
+		return i.Origin + ": " + i.Message
+	}
+	return i.Message
+}
+
+// ValidationError konfigürasyonda bulunan tüm hatalar
+type ValidationError struct {
+	Issues []Issue
+}
+
+// Error hataları tek satırda birleştirir
+func (e *ValidationError) Error() string {
+	if len(e.Issues) == 1 {
+		return e.Issues[0].String()
+	}
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.String()
+	}
+	return fmt.Sprintf("%d hata: %s", len(e.Issues), strings.Join(msgs, "; "))
+}
+
+// Validate konfigürasyonu doğrular. İlk hatada durmaz; bulunan tüm hatalar
+// *ValidationError olarak döner. Uyarılar hata sayılmaz, Check ile alınabilir.
+func (c *Config) Validate() error {
+	if errs, _ := c.Check(); len(errs) > 0 {
+		return &ValidationError{Issues: errs}
+	}
+	return nil
+}
+
+// Check konfigürasyonun tüm bölümlerini kontrol eder ve hataları ve uyarıları
+// (kullanımdan kalkan alanlar, etkisiz ayarlar) ayrı ayrı döndürür
+func (c *Config) Check() (errs, warnings []Issue) {
+	v := &validator{c: c, warnings: append([]Issue(nil), c.warnings...)}
+	v.dashboard()
+	v.logging()
+	v.metrics()
+	v.history()
+	v.storage()
+	v.alerts()
+	return v.errs, v.warnings
+}
+
+// ValidateFile config dosyasını parçaları ve ortam değişkenleriyle birlikte
+// yükleyip doğrular. Load'dan farklı olarak bilinmeyen alanlarda ve tip
+// uyuşmazlıklarında durmaz; bunlar diğer hatalarla birlikte döner. err sadece
+// dosya okunamadığında veya parse edilemediğinde döner.
+func ValidateFile(configPath string) (errs, warnings []Issue, err error) {
+	cfg, fileErrs, err := load(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	errs, warnings = cfg.Check()
+	return append(fileErrs, errs...), warnings, nil
+}
+
+// validator doğrulama sırasında bulunan sorunları toplar
+type validator struct {
+	c        *Config
+	errs     []Issue
+	warnings []Issue
+}
+
+// errorf yola ait bir hata ekler
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, v.issue(path, fmt.Sprintf(format, args...)))
+}
+
+// warnf yola ait bir uyarı ekler
+func (v *validator) warnf(path, format string, args ...interface{}) {
+	v.warnings = append(v.warnings, v.issue(path, fmt.Sprintf(format, args...)))
+}
+
+// issue yolun kaynağını da içeren bir sorun oluşturur
+func (v *validator) issue(path, msg string) Issue {
+	origin := v.c.Origin(path)
+	if origin == OriginDefault {
+		origin = ""
+	}
+	return Issue{Path: path, Message: msg, Origin: origin}
+}
+
+// options kaynak veya kanal seçeneklerini tipin seçenek struct'ına çözmeyi dener;
+// bilinmeyen seçenekler ve tip hataları yola ait hata olarak eklenir
+func (v *validator) options(path string, options map[string]interface{}, newOptions OptionsFunc) {
+	if newOptions == nil {
+		return
+	}
+	if err := DecodeOptions(options, newOptions()); err != nil {
+		v.errorf(path, "seçenekler geçersiz: %s", strings.TrimPrefix(err.Error(), "json: "))
+	}
+}
+
+// dashboard dashboard ayarlarını kontrol eder
+func (v *validator) dashboard() {
+	d := v.c.Dashboard
+	if d.Port < 1024 || d.Port > 65535 {
+		v.errorf("dashboard.port", "port geçersiz: %d (1024-65535 arası olmalı)", d.Port)
+	}
+	if err := checkHost(d.Host); err != nil {
+		v.errorf("dashboard.host", "%v", err)
+	}
+}
+
+// checkHost dinlenecek adresin IP adresi veya geçerli bir host ismi olduğunu kontrol eder.
+// Boş adres tüm arayüzleri dinler.
+func checkHost(host string) error {
+	if host == "" || net.ParseIP(host) != nil {
+		return nil
+	}
+	if _, port, err := net.SplitHostPort(host); err == nil {
+		return fmt.Errorf("host port içermemeli: %q (port alanını kullanın: %s)", host, port)
+	}
+	name := strings.TrimSuffix(host, ".")
+	if len(name) == 0 || len(name) > 253 {
+		return fmt.Errorf("host geçersiz: %q", host)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("host geçersiz: %q (IP adresi veya host ismi olmalı)", host)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("host geçersiz: %q (IP adresi veya host ismi olmalı)", host)
+			}
+		}
+	}
+	return nil
+}
+
+// logging logging ayarlarını ve birbiriyle tutarlılığını kontrol eder
+func (v *validator) logging() {
+	l := v.c.Logging
+	switch l.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		v.errorf("logging.level", "geçersiz log level: %q (debug, info, warn, error olmalı)", l.Level)
+	}
+	if l.Format != "" && l.Format != "text" && l.Format != "json" {
+		v.errorf("logging.format", "geçersiz log format: %q (text, json olmalı)", l.Format)
+	}
+
+	if l.MaxSizeMB < 0 {
+		v.errorf("logging.max_size_mb", "max_size_mb negatif olamaz")
+	}
+	if l.MaxAgeHours < 0 {
+		v.errorf("logging.max_age_hours", "max_age_hours negatif olamaz")
+	}
+	if l.MaxBackups < 0 {
+		v.errorf("logging.max_backups", "max_backups negatif olamaz")
+	}
+
+	switch l.Output {
+	case "", "stdout", "stderr":
+		// Örnek config'ler varsayılan dosya adını içerir; sadece değiştirilmişse uyarılır
+		if l.Filename != "" && l.Filename != Default().Logging.Filename {
+			v.warnf("logging.filename", "output file olmadığından kullanılmıyor")
+		}
+	case "file":
+		if l.Filename == "" {
+			v.errorf("logging.filename", "output file iken filename boş olamaz")
+		} else if err := checkWritable(l.Filename); err != nil {
+			v.errorf("logging.filename", "log dosyası yazılamıyor: %v", err)
+		}
+	default:
+		v.errorf("logging.output", "geçersiz log output: %q (stdout, stderr, file olmalı)", l.Output)
+	}
+}
+
+// checkWritable dosyanın yazılabilir olduğunu veya oluşturulabileceğini kontrol eder.
+// Rotasyon dosyayı yeniden adlandırdığından var olan en yakın üst dizinin de
+// yazılabilir olması gerekir. Kontrol yalnızca stat ve erişim izinleriyle yapılır,
+// dosya sistemine bir şey yazılmaz.
+func checkWritable(path string) error {
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%s bir dizin", path)
+		}
+		if err := canWrite(path); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	// Logger eksik dizinleri oluşturur; var olan ilk üst dizin kontrol edilir
+	dir := filepath.Dir(path)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s bir dizin değil", dir)
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if !os.IsNotExist(err) || parent == dir {
+			return err
+		}
+		dir = parent
+	}
+	return canWrite(dir)
+}
+
+// metrics metrik toplama ayarlarını, eklenti kaynaklarını ve process izleme listesini kontrol eder
+func (v *validator) metrics() {
+	m := v.c.Metrics
+	if m.Interval < 1 || m.Interval > 3600 {
+		v.errorf("metrics.interval", "interval geçersiz: %d (1-3600 saniye arası olmalı)", m.Interval)
+	}
+
+	if m.EnableProcesses && (m.ProcessTopN < 1 || m.ProcessTopN > 1000) {
+		v.errorf("metrics.process_top_n", "process top N geçersiz: %d (1-1000 arası olmalı)", m.ProcessTopN)
+	}
+	for i, key := range m.ProcessSortBy {
+		switch key {
+		case "cpu", "memory", "threads":
+		default:
+			v.warnf(indexPath("metrics.process_sort_by", i), "bilinmeyen sıralama anahtarı yok sayılıyor: %q (cpu, memory, threads)", key)
+		}
+	}
+
+	// Disk doluluk tahmini için en az 10 dakikalık geçmiş gerekir
+	if w := m.DiskForecastWindow; w != 0 && (w < 600 || w < 3*m.Interval) {
+		v.errorf("metrics.disk_forecast_window", "disk_forecast_window geçersiz: %d (0 veya en az 600 saniye ve 3 interval olmalı)", w)
+	}
+	if m.DiskForecastWindow > 0 && !v.c.History.Enabled && !v.c.Storage.Enabled {
+		v.warnf("metrics.disk_forecast_window", "tahmin geçmiş verisiyle yapılır; history ve storage kapalıyken tahmin üretilmez")
+	}
+
+	// Seçeneklerin anlamı (ör. dosyanın varlığı) collector başlatılırken doğrulanır;
+	// burada sadece bilinmeyen anahtarlar ve tip hataları aranır
+	sourceIDs := make(map[string]bool, len(m.Sources))
+	for i, src := range m.Sources {
+		path := indexPath("metrics.sources", i)
+		if src.Type == "" {
+			v.errorf(path+".type", "type boş olamaz")
+			continue
+		}
+		if ok, options, names := checkType(sourceTypes, src.Type); !ok {
+			v.errorf(path+".type", "bilinmeyen kaynak tipi: %q (kayıtlı: %s)", src.Type, strings.Join(names, ", "))
+		} else {
+			v.options(path+".options", src.Options, options)
+		}
+		id := src.ID
+		if id == "" {
+			id = src.Type
+		}
+		if sourceIDs[id] {
+			v.errorf(path, "kaynak ismi tekrar ediyor: %s (id ile ayırt edin)", id)
+		}
+		sourceIDs[id] = true
+		if src.Timeout < 0 {
+			v.errorf(path+".timeout", "timeout negatif olamaz")
+		}
+	}
+
+	names := make(map[string]bool, len(m.Watchlist))
+	for i, w := range m.Watchlist {
+		path := indexPath("metrics.watchlist", i)
+		if w.Name == "" {
+			v.errorf(path+".name", "isim boş olamaz")
+		} else if names[w.Name] {
+			v.errorf(path+".name", "isim tekrar ediyor: %s", w.Name)
+		}
+		names[w.Name] = true
+
+		if w.ProcessName == "" && w.Exe == "" && w.Cmdline == "" && w.Pidfile == "" {
+			v.errorf(path, "process_name, exe, cmdline veya pidfile belirtilmeli")
+		}
+		if w.Cmdline != "" {
+			if _, err := regexp.Compile(w.Cmdline); err != nil {
+				v.errorf(path+".cmdline", "cmdline regex geçersiz: %v", err)
+			}
+		}
+	}
+}
+
+// history bellek içi geçmiş ayarlarını kontrol eder
+func (v *validator) history() {
+	h := v.c.History
+	if h.Retention < 0 {
+		v.errorf("history.retention", "retention negatif olamaz")
+	}
+	if h.MaxSamples < 0 {
+		v.errorf("history.max_samples", "max_samples negatif olamaz")
+	}
+	if h.Enabled && h.Retention == 0 && h.MaxSamples == 0 {
+		v.errorf("history", "history etkinken retention veya max_samples belirtilmeli")
+	}
+}
+
+// storage kalıcı depolama ve rollup ayarlarını kontrol eder
+func (v *validator) storage() {
+	s := v.c.Storage
+	if !s.Enabled {
+		return
+	}
+	interval := v.c.Metrics.Interval
+
+	if s.DataDir == "" {
+		v.errorf("storage.data_dir", "data_dir boş olamaz")
+	}
+	if s.Retention < 0 {
+		v.errorf("storage.retention", "retention negatif olamaz")
+	}
+	if s.MaxDiskMB < 0 {
+		v.errorf("storage.max_disk_mb", "max_disk_mb negatif olamaz")
+	}
+	if s.BlockDuration < 60 || s.BlockDuration < interval {
+		v.errorf("storage.block_duration", "block_duration geçersiz: %d (en az 60 saniye ve interval'dan büyük olmalı)", s.BlockDuration)
+	}
+
+	resolutions := make(map[int]bool)
+	for i, r := range s.Rollups {
+		path := indexPath("storage.rollups", i)
+		if r.Resolution < 60 || r.Resolution < interval {
+			v.errorf(path+".resolution", "rollup resolution geçersiz: %d (en az 60 saniye ve interval'dan büyük olmalı)", r.Resolution)
+		}
+		if r.Retention < 0 {
+			v.errorf(path+".retention", "rollup retention negatif olamaz: %d", r.Retention)
+		}
+		if resolutions[r.Resolution] {
+			v.errorf(path+".resolution", "aynı çözünürlükte birden fazla rollup: %d", r.Resolution)
+		}
+		resolutions[r.Resolution] = true
+	}
+}
+
+// maxMaintenanceDuration bir bakım penceresinin en uzun süresi (7 gün)
+const maxMaintenanceDuration = 7 * 24 * 3600
+
+// validAlertOps desteklenen karşılaştırma operatörleri
+var validAlertOps = map[string]bool{">": true, ">=": true, "<": true, "<=": true, "==": true, "!=": true}
+
+// validSeverities desteklenen alarm önem seviyeleri
+var validSeverities = map[string]bool{"": true, "info": true, "warning": true, "critical": true}
+
+// alerts alarm kurallarını, bildirim kanallarını ve bakım pencerelerini kontrol eder
+func (v *validator) alerts() {
+	names := make(map[string]bool)
+	for i, r := range v.c.Alerts.Rules {
+		path := indexPath("alerts.rules", i)
+		if r.Name == "" {
+			v.errorf(path+".name", "name boş olamaz")
+		} else if names[r.Name] {
+			v.errorf(path+".name", "aynı isimle birden fazla alarm kuralı: %s", r.Name)
+		}
+		names[r.Name] = true
+
+		if r.Metric == "" {
+			v.errorf(path+".metric", "metric boş olamaz")
+		}
+		if !validAlertOps[r.Op] {
+			v.errorf(path+".op", "geçersiz op: %q (>, >=, <, <=, ==, !=)", r.Op)
+		}
+		if !validSeverities[r.Severity] {
+			v.errorf(path+".severity", "geçersiz severity: %q (info, warning, critical)", r.Severity)
+		}
+		if r.For < 0 {
+			v.errorf(path+".for", "for negatif olamaz")
+		}
+		if r.Clear != nil {
+			// Kapanma eşiği alarm eşiğinin "iyi" tarafında olmalı
+			if (r.Op == ">" || r.Op == ">=") && *r.Clear > r.Threshold {
+				v.errorf(path+".clear", "clear (%g) threshold'dan (%g) büyük olamaz", *r.Clear, r.Threshold)
+			}
+			if (r.Op == "<" || r.Op == "<=") && *r.Clear < r.Threshold {
+				v.errorf(path+".clear", "clear (%g) threshold'dan (%g) küçük olamaz", *r.Clear, r.Threshold)
+			}
+		}
+	}
+
+	channels := make(map[string]bool)
+	for i, ch := range v.c.Alerts.Channels {
+		path := indexPath("alerts.channels", i)
+		if ch.Name == "" {
+			v.errorf(path+".name", "name boş olamaz")
+		} else if channels[ch.Name] {
+			v.errorf(path+".name", "aynı isimle birden fazla bildirim kanalı: %s", ch.Name)
+		}
+		channels[ch.Name] = true
+
+		if ch.Type == "" {
+			v.errorf(path+".type", "type boş olamaz")
+		} else if ok, options, names := checkType(channelTypes, ch.Type); !ok {
+			v.errorf(path+".type", "bilinmeyen kanal tipi: %q (kayıtlı: %s)", ch.Type, strings.Join(names, ", "))
+		} else {
+			v.options(path+".options", ch.Options, options)
+		}
+		for j, s := range ch.States {
+			if s != "pending" && s != "firing" && s != "resolved" {
+				v.errorf(indexPath(path+".states", j), "geçersiz state: %q (pending, firing, resolved)", s)
+			}
+		}
+		if !validSeverities[ch.MinSeverity] {
+			v.errorf(path+".min_severity", "geçersiz min_severity: %q (info, warning, critical)", ch.MinSeverity)
+		}
+		if ch.Timeout < 0 {
+			v.errorf(path+".timeout", "timeout negatif olamaz")
+		}
+		if ch.Retries < 0 {
+			v.errorf(path+".retries", "retries negatif olamaz")
+		}
+	}
+
+	windows := make(map[string]bool)
+	for i, w := range v.c.Alerts.Maintenance {
+		path := indexPath("alerts.maintenance", i)
+		if w.Name == "" {
+			v.errorf(path+".name", "name boş olamaz")
+		} else if windows[w.Name] {
+			v.errorf(path+".name", "aynı isimle birden fazla bakım penceresi: %s", w.Name)
+		}
+		windows[w.Name] = true
+
+		if _, err := cron.Parse(w.Schedule); err != nil {
+			v.errorf(path+".schedule", "%v", err)
+		}
+		if w.Duration < 60 || w.Duration > maxMaintenanceDuration {
+			v.errorf(path+".duration", "duration 60 ile %d saniye arasında olmalı", maxMaintenanceDuration)
+		}
+	}
+}
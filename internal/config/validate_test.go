@@ -0,0 +1,295 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// issuePaths sorunların json yollarını döndürür
+func issuePaths(issues []Issue) []string {
+	paths := make([]string, len(issues))
+	for i, issue := range issues {
+		paths[i] = issue.Path
+	}
+	return paths
+}
+
+// Kanal ve kaynak tipleri normalde notify ve metrics paketlerinden kaydedilir
+func init() {
+	RegisterChannelType("webhook", func() interface{} {
+		return &struct {
+			URL     string `json:"url"`
+			Retries int    `json:"retries"`
+		}{}
+	})
+	RegisterSourceType("file", nil)
+}
+
+func TestValidateCollectsAll(t *testing.T) {
+	cfg := Default()
+	cfg.Dashboard.Port = 80
+	cfg.Dashboard.Host = "bad host"
+	cfg.Logging.Format = "xml"
+	cfg.Metrics.Interval = 0
+	cfg.Alerts.Rules[2].Op = "=>"
+	cfg.Alerts.Rules[3].Severity = "fatal"
+
+	err := cfg.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	want := []string{"dashboard.port", "dashboard.host", "logging.format", "metrics.interval", "alerts.rules[2].op", "alerts.rules[3].severity"}
+	got := issuePaths(verr.Issues)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected issues at %v, got %v", want, got)
+	}
+	if !strings.HasPrefix(err.Error(), "6 hata: ") {
+		t.Errorf("Expected error to report all issues, got %q", err.Error())
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	valid := []string{"", "localhost", "0.0.0.0", "::1", "fe80::1", "monitor-01.example.com", "example.com."}
+	for _, host := range valid {
+		if err := checkHost(host); err != nil {
+			t.Errorf("Expected host %q to be valid, got %v", host, err)
+		}
+	}
+
+	invalid := []string{"localhost:8080", "[::1]:8080", "bad host", "-lead.example.com", "a..b", "http://localhost"}
+	for _, host := range invalid {
+		if err := checkHost(host); err == nil {
+			t.Errorf("Expected host %q to be invalid", host)
+		}
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	// Eksik dizinler logger tarafından oluşturulur
+	if err := checkWritable(filepath.Join(dir, "logs", "app", "syswatch.log")); err != nil {
+		t.Errorf("Expected missing directories to be creatable, got %v", err)
+	}
+
+	notDir := filepath.Join(dir, "file")
+	if err := os.WriteFile(notDir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkWritable(filepath.Join(notDir, "syswatch.log")); err == nil {
+		t.Error("Expected error for log file under a regular file")
+	}
+	if err := checkWritable(dir); err == nil {
+		t.Error("Expected error for directory as log file")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected writability check to leave no files behind, got %d entries", len(entries))
+	}
+
+	// Kontrol dizine hiçbir şey yazmamalı; dizin zamanı değişmemeli
+	before, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkWritable(filepath.Join(dir, "syswatch.log")); err != nil {
+		t.Errorf("Expected writable directory, got %v", err)
+	}
+	if after, err := os.Stat(dir); err != nil || !after.ModTime().Equal(before.ModTime()) {
+		t.Error("Expected writability check not to modify the directory")
+	}
+
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+		readOnly := filepath.Join(dir, "ro")
+		if err := os.Mkdir(readOnly, 0555); err != nil {
+			t.Fatal(err)
+		}
+		if err := checkWritable(filepath.Join(readOnly, "syswatch.log")); err == nil {
+			t.Error("Expected error for read-only directory")
+		}
+	}
+}
+
+func TestLoadUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.json": `{
+			"include": "conf.d",
+			"metrics": {"intervall": 10},
+			"alerts": {"rules": [{"name": "cpu", "metric": "cpu.usage", "op": ">", "thresold": 90}]}
+		}`,
+		"conf.d/10-notify.yaml": "alerts:\n  channels:\n    - name: ops\n      type: webhook\n      options: {url: http://x}\n      retry: 3\n",
+	})
+	configPath := filepath.Join(dir, "config.json")
+
+	_, err := Load(configPath)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError for unknown fields, got %v", err)
+	}
+
+	want := map[string]string{
+		"alerts.rules[0].thresold": configPath,
+		"metrics.intervall":        configPath,
+		"alerts.channels[0].retry": filepath.Join(dir, "conf.d", "10-notify.yaml"),
+	}
+	if len(verr.Issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %v", len(want), verr.Issues)
+	}
+	for _, issue := range verr.Issues {
+		if origin, ok := want[issue.Path]; !ok || issue.Origin != origin {
+			t.Errorf("Unexpected issue %s", issue)
+		}
+	}
+	if !strings.Contains(err.Error(), `"interval" mı demek istediniz?`) {
+		t.Errorf("Expected suggestion for typo, got %q", err.Error())
+	}
+
+	// ValidateFile bilinmeyen alanlarda durmadan diğer hataları da döndürür
+	errs, _, err := ValidateFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != len(want) {
+		t.Errorf("Expected %d errors from ValidateFile, got %v", len(want), errs)
+	}
+}
+
+func TestValidateFileTypeErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.json": `{
+			"include": "conf.d",
+			"metrics": {"interval": "5s", "intervall": 3},
+			"dashboard": {"port": -1}
+		}`,
+		"conf.d/10-rules.yaml": "alerts:\n  rules+:\n    - name: x\n      metric: cpu.usage\n      op: '>'\n      threshold: high\n      labels: {team: [ops]}\n",
+	})
+	configPath := filepath.Join(dir, "config.json")
+	fragment := filepath.Join(dir, "conf.d", "10-rules.yaml")
+
+	// Tip hatası diğer dosyaların ve alanların kontrolünü durdurmamalı
+	errs, _, err := ValidateFile(configPath)
+	if err != nil {
+		t.Fatalf("Expected type errors to be reported as issues, got %v", err)
+	}
+	want := map[string]string{
+		"metrics.interval":             configPath,
+		"metrics.intervall":            configPath,
+		"alerts.rules+[0].threshold":   fragment,
+		"alerts.rules+[0].labels.team": fragment,
+		"dashboard.port":               configPath,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), errs)
+	}
+	for _, issue := range errs {
+		if origin, ok := want[issue.Path]; !ok || issue.Origin != origin {
+			t.Errorf("Unexpected issue %s", issue)
+		}
+	}
+	if got := errs[0].Message; got != "tam sayı bekleniyor, metin verildi" {
+		t.Errorf("Unexpected type error message %q", got)
+	}
+
+	var verr *ValidationError
+	if _, err := Load(configPath); !errors.As(err, &verr) {
+		t.Errorf("Expected *ValidationError from Load, got %v", err)
+	}
+}
+
+func TestCheckTypes(t *testing.T) {
+	cfg := Default()
+	cfg.Metrics.Sources = []SourceConfig{{Type: "file"}, {Type: "fiel"}}
+	cfg.Alerts.Channels = []NotifyChannelConfig{
+		{Name: "ops", Type: "webhook"},
+		{Name: "mail", Type: "email"},
+	}
+
+	errs, _ := cfg.Check()
+	paths := strings.Join(issuePaths(errs), " ")
+	if len(errs) != 2 || paths != "metrics.sources[1].type alerts.channels[1].type" {
+		t.Errorf("Expected unknown source and channel types, got %v", errs)
+	}
+	if !strings.Contains(errs[1].Message, "webhook") {
+		t.Errorf("Expected registered types in message, got %q", errs[1].Message)
+	}
+}
+
+func TestCheckOptions(t *testing.T) {
+	cfg := Default()
+	cfg.Metrics.Sources = []SourceConfig{{Type: "file", Options: map[string]interface{}{"anything": 1}}}
+	cfg.Alerts.Channels = []NotifyChannelConfig{
+		{Name: "ops", Type: "webhook", Options: map[string]interface{}{"url": "http://x", "retries": 3}},
+		{Name: "typo", Type: "webhook", Options: map[string]interface{}{"url": "http://x", "intervall": 3}},
+		{Name: "type", Type: "webhook", Options: map[string]interface{}{"retries": "3"}},
+	}
+
+	// Seçenek struct'ı kaydetmeyen tiplerin seçenekleri kontrol edilmez
+	errs, _ := cfg.Check()
+	paths := strings.Join(issuePaths(errs), " ")
+	if paths != "alerts.channels[1].options alerts.channels[2].options" {
+		t.Fatalf("Expected invalid channel options to be reported, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Message, `"intervall"`) {
+		t.Errorf("Expected unknown option in message, got %q", errs[0].Message)
+	}
+}
+
+func TestDecodeOptions(t *testing.T) {
+	var opts struct {
+		URL     string `json:"url"`
+		Timeout int    `json:"timeout"`
+	}
+	if err := DecodeOptions(map[string]interface{}{"url": "http://x", "timeout": 5}, &opts); err != nil {
+		t.Fatalf("DecodeOptions() failed: %v", err)
+	}
+	if opts.URL != "http://x" || opts.Timeout != 5 {
+		t.Errorf("Unexpected options: %+v", opts)
+	}
+
+	// Yazım hataları sessizce yok sayılmamalı
+	if err := DecodeOptions(map[string]interface{}{"ulr": "http://x"}, &opts); err == nil {
+		t.Error("Expected error for unknown option")
+	}
+	if err := DecodeOptions(map[string]interface{}{"timeout": "5s"}, &opts); err == nil {
+		t.Error("Expected error for wrong option type")
+	}
+}
+
+func TestCheckWarnings(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.yaml": "daemon:\n  version: 0.1.0\nlogging:\n  filename: /var/log/syswatch.log\nmetrics:\n  process_sort_by: [cpu, io]\n",
+	})
+
+	cfg, err := Load(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, warnings := cfg.Check()
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+	want := []string{"daemon.version", "logging.filename", "metrics.process_sort_by[1]"}
+	if got := issuePaths(warnings); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected warnings at %v, got %v", want, warnings)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected warnings not to fail validation, got %v", err)
+	}
+
+	// Disk tahmini geçmiş olmadan çalışamaz
+	cfg = Default()
+	cfg.History.Enabled = false
+	if _, warnings := cfg.Check(); strings.Join(issuePaths(warnings), ",") != "metrics.disk_forecast_window" {
+		t.Errorf("Expected forecast warning without history, got %v", warnings)
+	}
+}
@@ -0,0 +1,166 @@
+// Package cron bakım pencereleri için cron benzeri zamanlama ifadelerini çözümler.
+//
+// Desteklenen biçim beş alanlıdır: dakika saat ayın-günü ay haftanın-günü.
+// Her alanda *, liste (1,15), aralık (1-5) ve adım (*/10, 0-30/5) kullanılabilir;
+// haftanın gününde 0 ve 7 pazardır. @hourly, @daily, @weekly ve @monthly kısaltmaları
+// da kabul edilir. Zamanlar yerel saat dilimine göre değerlendirilir.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule derlenmiş zamanlama ifadesi
+type Schedule struct {
+	minute, hour, dom, month, dow uint64 // Her alan için izin verilen değerlerin bit maskesi
+
+	// Ayın günü ve haftanın günü birlikte kısıtlanmışsa cron geleneğine uygun
+	// olarak ikisinden birinin eşleşmesi yeterlidir
+	domStar, dowStar bool
+}
+
+// aliases kısaltmaların karşılıkları
+var aliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// field bir alanın geçerli değer aralığı
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"dakika", 0, 59},
+	{"saat", 0, 23},
+	{"ayın günü", 1, 31},
+	{"ay", 1, 12},
+	{"haftanın günü", 0, 7},
+}
+
+// Parse zamanlama ifadesini derler
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if alias, ok := aliases[spec]; ok {
+		spec = alias
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("zamanlama 5 alan içermeli (dakika saat gün ay haftanın-günü): %q", spec)
+	}
+
+	var masks [5]uint64
+	for i, part := range parts {
+		mask, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		masks[i] = mask
+	}
+
+	// 7 de pazar anlamına gelir
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+	}
+
+	return &Schedule{
+		minute:  masks[0],
+		hour:    masks[1],
+		dom:     masks[2],
+		month:   masks[3],
+		dow:     masks[4],
+		domStar: strings.HasPrefix(parts[2], "*"),
+		dowStar: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseField tek bir alanı bit maskesine çevirir
+func parseField(part string, f field) (uint64, error) {
+	var mask uint64
+
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s < 1 {
+				return 0, fmt.Errorf("%s alanında geçersiz adım: %q", f.name, item)
+			}
+			rangePart, step = item[:i], s
+		}
+
+		lo, hi := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("%s alanında geçersiz değer: %q", f.name, item)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("%s alanında geçersiz değer: %q", f.name, item)
+				}
+			} else if step > 1 {
+				// "5/15" gibi ifadeler başlangıçtan alan sonuna kadar geçerlidir
+				hi = f.max
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%s alanı %d-%d aralığında olmalı: %q", f.name, f.min, f.max, item)
+		}
+
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+
+	return mask, nil
+}
+
+// Matches verilen zamanın (dakika hassasiyetinde) zamanlamaya uyup uymadığını döndürür
+func (s *Schedule) Matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// LastStart t'den önceki en fazla within süre içinde zamanlamanın son tetiklendiği dakikayı
+// döndürür. Böyle bir an yoksa false döner.
+func (s *Schedule) LastStart(t time.Time, within time.Duration) (time.Time, bool) {
+	start := t.Truncate(time.Minute)
+	for c := start; t.Sub(c) < within; c = c.Add(-time.Minute) {
+		if s.Matches(c) {
+			return c, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Next t'den sonraki ilk tetiklenme zamanını döndürür (en fazla bir yıl ileriye bakılır)
+func (s *Schedule) Next(t time.Time) (time.Time, bool) {
+	c := t.Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(1, 0, 1)
+	for c.Before(end) {
+		if s.Matches(c) {
+			return c, true
+		}
+		c = c.Add(time.Minute)
+	}
+	return time.Time{}, false
+}
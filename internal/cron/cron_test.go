@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	}
+	for _, spec := range invalid {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	// 2024-03-10 pazar
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.March, day, hour, minute, 0, 0, time.Local)
+	}
+
+	testCases := []struct {
+		spec  string
+		t     time.Time
+		match bool
+	}{
+		{"30 2 * * 0", at(10, 2, 30), true},
+		{"30 2 * * 7", at(10, 2, 30), true},
+		{"30 2 * * 1-5", at(10, 2, 30), false},
+		{"*/15 * * * *", at(11, 4, 45), true},
+		{"*/15 * * * *", at(11, 4, 46), false},
+		{"0 22-23,0-4 * * *", at(12, 3, 0), true},
+		{"@daily", at(12, 0, 0), true},
+		{"@weekly", at(11, 0, 0), false},
+		// Gün ve haftanın günü birlikte kısıtlıysa biri yeterli
+		{"0 0 1 * 0", at(10, 0, 0), true},
+		{"0 0 1 * 1", at(10, 0, 0), false},
+	}
+
+	for _, tc := range testCases {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.spec, err)
+		}
+		if got := s.Matches(tc.t); got != tc.match {
+			t.Errorf("%q at %s: expected %v, got %v", tc.spec, tc.t, tc.match, got)
+		}
+	}
+}
+
+func TestLastStartAndNext(t *testing.T) {
+	s, err := Parse("0 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, time.March, 10, 3, 30, 0, 0, time.Local)
+
+	start, ok := s.LastStart(now, 2*time.Hour)
+	if !ok || !start.Equal(time.Date(2024, time.March, 10, 2, 0, 0, 0, time.Local)) {
+		t.Errorf("Unexpected last start: %v %v", start, ok)
+	}
+	if _, ok := s.LastStart(now, time.Hour); ok {
+		t.Error("Expected no start within the last hour")
+	}
+
+	next, ok := s.Next(now)
+	if !ok || !next.Equal(time.Date(2024, time.March, 11, 2, 0, 0, 0, time.Local)) {
+		t.Errorf("Unexpected next start: %v %v", next, ok)
+	}
+}
@@ -2,22 +2,42 @@ package daemon
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/karsterr/syswatch-daemon/internal/alert"
 	"github.com/karsterr/syswatch-daemon/internal/config"
 	"github.com/karsterr/syswatch-daemon/internal/dashboard"
+	"github.com/karsterr/syswatch-daemon/internal/history"
 	"github.com/karsterr/syswatch-daemon/internal/logger"
 	"github.com/karsterr/syswatch-daemon/internal/metrics"
+	"github.com/karsterr/syswatch-daemon/internal/notify"
+	"github.com/karsterr/syswatch-daemon/internal/silence"
+	"github.com/karsterr/syswatch-daemon/internal/storage"
 )
 
 // Daemon ana daemon yapısı
 type Daemon struct {
 	mu            sync.RWMutex
 	running       bool
+	stateMu       sync.RWMutex // config ve metricsCol'u korur (Reload ile değişebilir)
 	config        *config.Config
 	metricsCol    *metrics.Collector
+	store         *metrics.Store
+	history       *history.Buffer
+	historyQuery  *history.Fallback
+	storage       *storage.DB
+	alerts        *alert.Engine
+	notifier      *notify.Notifier
+	silencer      *silence.Silencer
 	dashboardSrv  *dashboard.Server
+	reloadMu      sync.Mutex
+	reloadChan    chan struct{} // Metrik aralığı değiştiğinde ana döngüye haber verir
+	configPath    string
+	loadConfig    func() (*config.Config, error)
+	version       string // Dashboard health endpoint'inde gösterilen sürüm
 	stopChan      chan struct{}
 	wg            sync.WaitGroup
 }
@@ -29,19 +49,73 @@ func New() *Daemon {
 
 // NewWithConfig belirtilen konfigürasyon ile yeni daemon instance oluşturur
 func NewWithConfig(cfg *config.Config) *Daemon {
-	metricsCol := metrics.NewCollector()
+	store := metrics.NewStore()
 	
-	var dashboardSrv *dashboard.Server
-	if cfg.Dashboard.Enabled {
-		dashboardSrv = dashboard.NewServer(metricsCol, cfg.Dashboard.Port)
+	var historyBuf *history.Buffer
+	if cfg.History.Enabled {
+		historyBuf = history.New(
+			cfg.History.Capacity(cfg.Metrics.Interval),
+			time.Duration(cfg.History.Retention)*time.Second,
+		)
+	}
+	
+	// Kalıcı depolama Start'ta açıldığında arşiv olarak eklenir
+	historyQuery := &history.Fallback{Recent: historyBuf}
+	
+	var alerts *alert.Engine
+	if cfg.Alerts.Enabled {
+		alerts = alert.NewEngine(cfg.Alerts.Rules)
 	}
 	
-	return &Daemon{
+	d := &Daemon{
 		config:       cfg,
-		metricsCol:   metricsCol,
-		dashboardSrv: dashboardSrv,
+		store:        store,
+		history:      historyBuf,
+		historyQuery: historyQuery,
+		alerts:       alerts,
+		reloadChan:   make(chan struct{}, 1),
 		stopChan:     make(chan struct{}),
 	}
+	d.metricsCol = d.newCollector(cfg)
+	if cfg.Dashboard.Enabled {
+		d.dashboardSrv = d.newDashboard(cfg)
+	}
+	return d
+}
+
+// newCollector metrics collector'ı oluşturur; geçmiş veya depolama açıksa disk
+// doluluk tahmini bu kaynaklardan beslenir
+func (d *Daemon) newCollector(cfg *config.Config) *metrics.Collector {
+	collector := metrics.NewCollectorWithConfig(cfg.Metrics)
+	if cfg.History.Enabled || cfg.Storage.Enabled {
+		collector.SetUsageHistory(usageHistory{querier: d.historyQuery})
+	}
+	return collector
+}
+
+// newDashboard dashboard sunucusunu oluşturur ve daemon bileşenlerine bağlar
+func (d *Daemon) newDashboard(cfg *config.Config) *dashboard.Server {
+	srv := dashboard.NewServer(d.store, cfg.Dashboard.Host, cfg.Dashboard.Port)
+	srv.SetAdminToken(cfg.Dashboard.AdminToken)
+	if d.version != "" {
+		srv.SetVersion(d.version)
+	}
+	if cfg.History.Enabled || cfg.Storage.Enabled {
+		srv.SetHistory(d.historyQuery)
+	}
+	if d.alerts != nil {
+		srv.SetAlerts(d.alerts)
+	}
+	if d.silencer != nil {
+		srv.SetSilencer(d.silencer)
+	}
+	if d.notifier != nil {
+		srv.SetNotifier(d.notifier)
+	}
+	if d.loadConfig != nil {
+		srv.SetReloader(d.reloadFromAPI)
+	}
+	return srv
 }
 
 // Start daemon'u başlatır
@@ -56,14 +130,56 @@ func (d *Daemon) Start(ctx context.Context) error {
 	log := logger.GetLogger()
 	log.Info("Daemon başlatılıyor...")
 
+	// Kalıcı depolamayı aç (WAL kurtarması burada yapılır)
+	if d.config.Storage.Enabled {
+		db, err := storage.Open(d.config.Storage.DataDir, storageOptions(d.config.Storage))
+		if err != nil {
+			return fmt.Errorf("depolama açılamadı: %w", err)
+		}
+		d.storage = db
+		d.historyQuery.Archive = db
+	}
+	
+	// Kayıtlı susturmaları yükle
+	if d.alerts != nil {
+		silencer, err := silence.New(d.config.Alerts.SilencesFile, d.config.Alerts.Maintenance)
+		if err != nil {
+			d.closeStorage(true)
+			return err
+		}
+		d.silencer = silencer
+		if d.dashboardSrv != nil {
+			d.dashboardSrv.SetSilencer(silencer)
+		}
+	}
+	
+	// Alarm bildirim kanallarını başlat (kanal yoksa da reload ile eklenebilmesi için oluşturulur)
+	if d.alerts != nil {
+		notifier, err := notify.New(d.config.Alerts.Channels)
+		if err != nil {
+			d.closeStorage(true)
+			return err
+		}
+		notifier.Start()
+		d.notifier = notifier
+		if d.dashboardSrv != nil {
+			d.dashboardSrv.SetNotifier(notifier)
+		}
+	}
+	
 	// Metrics collector'ı başlat
 	if err := d.metricsCol.Start(); err != nil {
+		d.stopNotifier(ctx, true)
+		d.closeStorage(true)
 		return err
 	}
 	
 	// Dashboard server'ını başlat (eğer etkin ise)
 	if d.config.Dashboard.Enabled && d.dashboardSrv != nil {
 		if err := d.dashboardSrv.Start(); err != nil {
+			d.metricsCol.Stop()
+			d.stopNotifier(ctx, true)
+			d.closeStorage(true)
 			return err
 		}
 	}
@@ -74,6 +190,18 @@ func (d *Daemon) Start(ctx context.Context) error {
 	d.wg.Add(1)
 	go d.mainLoop(ctx)
 
+	// Rollup katmanlarını periyodik olarak güncelle
+	if d.storage != nil && len(d.config.Storage.Rollups) > 0 {
+		d.wg.Add(1)
+		go d.rollupLoop(ctx, d.storage, rollupInterval(d.config.Storage.Rollups))
+	}
+	
+	// Config dosyası değişikliklerini izle
+	if d.config.Daemon.WatchConfig && d.loadConfig != nil && d.configPath != "" {
+		d.wg.Add(1)
+		go d.watchConfig(ctx)
+	}
+
 	log.Info("Daemon başarıyla başlatıldı")
 	return nil
 }
@@ -101,8 +229,10 @@ func (d *Daemon) Stop(ctx context.Context) error {
 	}()
 
 	// Timeout veya tamamlanma
+	drained := false
 	select {
 	case <-done:
+		drained = true
 		log.Info("Tüm işlemler temiz şekilde durduruldu")
 	case <-ctx.Done():
 		log.Warn("Shutdown timeout, zorla çıkılıyor")
@@ -118,11 +248,43 @@ func (d *Daemon) Stop(ctx context.Context) error {
 		}
 	}
 
+	// Kuyrukta kalan bildirimleri gönder ve depolamayı kapat. Zaman aşımında ana
+	// döngü hâlâ çalışıyor olabileceğinden alanlar sıfırlanmaz; kapatılmış depolama
+	// yazmaları hata ile reddeder, durdurulmuş notifier olayları yok sayar.
+	d.stopNotifier(ctx, drained)
+	d.closeStorage(drained)
+
 	d.running = false
 	log.Info("Daemon başarıyla durduruldu")
 	return nil
 }
 
+// closeStorage kalıcı depolamayı (açıksa) kapatır. release sadece ana döngü
+// çalışmıyorsa true olmalıdır; aksi halde döngü alanı okurken sıfırlanmış olur.
+func (d *Daemon) closeStorage(release bool) {
+	if d.storage == nil {
+		return
+	}
+	if err := d.storage.Close(); err != nil {
+		logger.GetLogger().Errorf("Depolama kapatılırken hata: %v", err)
+	}
+	if release {
+		d.storage = nil
+		d.historyQuery.Archive = nil
+	}
+}
+
+// stopNotifier bildirim kanallarını (başlatılmışsa) durdurur. release closeStorage'daki gibidir.
+func (d *Daemon) stopNotifier(ctx context.Context, release bool) {
+	if d.notifier == nil {
+		return
+	}
+	d.notifier.Stop(ctx)
+	if release {
+		d.notifier = nil
+	}
+}
+
 // IsRunning daemon'un çalışıp çalışmadığını kontrol eder
 func (d *Daemon) IsRunning() bool {
 	d.mu.RLock()
@@ -135,16 +297,23 @@ func (d *Daemon) mainLoop(ctx context.Context) {
 	defer d.wg.Done()
 
 	log := logger.GetLogger()
-	ticker := time.NewTicker(time.Duration(d.config.Metrics.Interval) * time.Second)
+	ticker := time.NewTicker(d.interval())
 	defer ticker.Stop()
 
 	log.Info("Ana iş döngüsü başlatıldı")
 
+	// Dashboard ilk tick'i beklemeden veri gösterebilsin diye hemen bir kez topla
+	d.collectAndProcessMetrics()
+
 	for {
 		select {
 		case <-ticker.C:
 			// Metrikleri topla ve işle
 			d.collectAndProcessMetrics()
+		case <-d.reloadChan:
+			interval := d.interval()
+			ticker.Reset(interval)
+			log.Infof("Metrik toplama aralığı güncellendi: %s", interval)
 		case <-d.stopChan:
 			log.Info("Stop sinyali alındı, ana döngü sonlandırılıyor")
 			return
@@ -155,23 +324,162 @@ func (d *Daemon) mainLoop(ctx context.Context) {
 	}
 }
 
+// interval geçerli metrik toplama aralığını döndürür
+func (d *Daemon) interval() time.Duration {
+	d.stateMu.RLock()
+	defer d.stateMu.RUnlock()
+	return time.Duration(d.config.Metrics.Interval) * time.Second
+}
+
+// rollupLoop ham verileri periyodik olarak rollup katmanlarına özetler. Aralık
+// Start'ta hesaplanır; Reload d.config'i değiştirebildiğinden burada okunmaz.
+func (d *Daemon) rollupLoop(ctx context.Context, db *storage.DB, interval time.Duration) {
+	defer d.wg.Done()
+
+	log := logger.GetLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Daemon kapalıyken biriken aralıklar hemen özetlenir
+	if err := db.Rollup(time.Now()); err != nil {
+		log.Errorf("Rollup hatası: %v", err)
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := db.Rollup(time.Now()); err != nil {
+				log.Errorf("Rollup hatası: %v", err)
+			}
+		case <-d.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// rollupInterval en ince katmanın çözünürlüğünü döndürür
+func rollupInterval(rollups []config.RollupConfig) time.Duration {
+	interval := rollups[0].Resolution
+	for _, r := range rollups[1:] {
+		if r.Resolution < interval {
+			interval = r.Resolution
+		}
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// storageOptions depolama config'ini storage ayarlarına dönüştürür
+func storageOptions(cfg config.StorageConfig) storage.Options {
+	opts := storage.Options{
+		BlockDuration: time.Duration(cfg.BlockDuration) * time.Second,
+		Retention:     time.Duration(cfg.Retention) * time.Second,
+		MaxBytes:      int64(cfg.MaxDiskMB) << 20,
+	}
+	for _, r := range cfg.Rollups {
+		opts.Rollups = append(opts.Rollups, storage.RollupTier{
+			Resolution: time.Duration(r.Resolution) * time.Second,
+			Retention:  time.Duration(r.Retention) * time.Second,
+		})
+	}
+	return opts
+}
+
 // collectAndProcessMetrics metrikleri toplar ve işler
 func (d *Daemon) collectAndProcessMetrics() {
 	log := logger.GetLogger()
 	
-	// Sistem metriklerini topla
+	// Sistem metriklerini topla (reload collector'ı toplama bitince değiştirir)
+	d.stateMu.RLock()
 	metrics, err := d.metricsCol.CollectAll()
+	d.stateMu.RUnlock()
 	if err != nil {
+		// Tüm alt sistemler başarısız olsa bile hata detayları snapshot'ta yayınlanır
 		log.Errorf("Metrikler toplanırken hata: %v", err)
+	}
+	if metrics == nil {
 		return
 	}
 
-	// Şu an için sadece log'la (ilerleyen aşamalarda dashboard'a gönderilecek)
-	log.Infof("CPU: %.1f%%, RAM: %.1f%%, Disk: %.1f%%, Network: R:%.2fMB/s W:%.2fMB/s", 
-		metrics.CPU.Usage,
-		metrics.Memory.Usage,
-		metrics.Disk.Usage,
-		float64(metrics.Network.BytesRecv)/(1024*1024),
-		float64(metrics.Network.BytesSent)/(1024*1024),
-	)
-}
\ No newline at end of file
+	// Okuyucular (dashboard) her zaman en son snapshot'ı görür
+	d.store.Publish(metrics)
+	if d.history != nil {
+		d.history.Add(metrics)
+	}
+	if d.storage != nil {
+		if err := d.storage.Append(metrics); err != nil {
+			log.Errorf("Metrikler diske yazılamadı: %v", err)
+		}
+	}
+
+	for subsystem, errMsg := range metrics.Errors {
+		log.Warnf("%s metrikleri toplanamadı: %s", subsystem, errMsg)
+	}
+
+	if d.alerts != nil {
+		// Susturulan alarmların durumu izlenir ama bildirimleri gönderilmez
+		var deliver []alert.Alert
+		for _, a := range d.alerts.Evaluate(metrics) {
+			silencedBy := d.silencer.Silenced(a, metrics.Timestamp)
+			logAlert(a, silencedBy)
+			if silencedBy == "" {
+				deliver = append(deliver, a)
+			}
+		}
+		if d.notifier != nil && len(deliver) > 0 {
+			d.notifier.Notify(deliver)
+		}
+	}
+
+	log.Info(formatMetricsSummary(metrics))
+}
+
+// logAlert alarm durum değişikliğini önem seviyesine uygun log seviyesiyle yazar
+// Susturulan alarmlar bilgi seviyesinde yazılır.
+func logAlert(a alert.Alert, silencedBy string) {
+	log := logger.GetLogger()
+	msg := fmt.Sprintf("Alarm %s: %s [%s] %s (değer: %.2f)", a.State, a.Rule, a.Severity, a.Summary, a.Value)
+	if silencedBy != "" {
+		msg += fmt.Sprintf(" - susturuldu (%s)", silencedBy)
+	}
+
+	switch {
+	case silencedBy != "", a.State == alert.StateResolved, a.State == alert.StatePending:
+		log.Info(msg)
+	case a.Severity == alert.SeverityCritical:
+		log.Error(msg)
+	default:
+		log.Warn(msg)
+	}
+}
+
+// formatMetricsSummary toplanan metrikleri tek satırlık özet haline getirir
+func formatMetricsSummary(m *metrics.SystemMetrics) string {
+	parts := make([]string, 0, 6)
+	if m.Host != nil {
+		parts = append(parts, fmt.Sprintf("Load: %.2f %.2f %.2f", m.Host.Load1, m.Host.Load5, m.Host.Load15))
+	}
+	if m.CPU != nil {
+		parts = append(parts, fmt.Sprintf("CPU: %.1f%%", m.CPU.Usage))
+	}
+	if m.Memory != nil {
+		parts = append(parts, fmt.Sprintf("RAM: %.1f%%, Swap: %.1f%%", m.Memory.Usage, m.Memory.Swap.Usage))
+	}
+	if m.Disk != nil {
+		parts = append(parts, fmt.Sprintf("Disk: %.1f%% (%s)", m.Disk.Usage, m.Disk.Fullest))
+	}
+	if m.DiskIO != nil {
+		parts = append(parts, fmt.Sprintf("Disk I/O: R:%.2fMB/s W:%.2fMB/s",
+			m.DiskIO.ReadBytesPerSec/(1024*1024),
+			m.DiskIO.WriteBytesPerSec/(1024*1024),
+		))
+	}
+	if m.Network != nil {
+		parts = append(parts, fmt.Sprintf("Network: R:%.2fMB/s W:%.2fMB/s",
+			m.Network.RecvBytesPerSec/(1024*1024),
+			m.Network.SentBytesPerSec/(1024*1024),
+		))
+	}
+	return strings.Join(parts, ", ")
+}
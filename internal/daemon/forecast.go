@@ -0,0 +1,45 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/history"
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// usageHistory disk doluluk tahminini bellek içi geçmiş ve kalıcı depolama
+// sorgularıyla besler; tahmin collector yeniden oluşturulduğunda sıfırlanmaz
+type usageHistory struct {
+	querier history.Querier
+}
+
+// Usage mountpoint'in kullanılan ve toplam alan serilerini step adımlarıyla sorgular.
+// Veri olmayan adımlar atlanır.
+func (u usageHistory) Usage(mountpoint string, from, to time.Time, step time.Duration) ([]metrics.UsageSample, error) {
+	used := metrics.FilesystemPath(mountpoint, "used")
+	total := metrics.FilesystemPath(mountpoint, "total")
+
+	result, err := u.querier.Query(history.Query{From: from, To: to, Step: step, Fields: []string{used, total}})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Series) != 2 {
+		return nil, fmt.Errorf("beklenmeyen seri sayısı: %d", len(result.Series))
+	}
+
+	totals := result.Series[1].Points
+	samples := make([]metrics.UsageSample, 0, len(result.Series[0].Points))
+	for i, p := range result.Series[0].Points {
+		if p.Avg == nil {
+			continue
+		}
+		// Adım ortalaması adımın ortasına yerleştirilir
+		s := metrics.UsageSample{Time: p.Time.Add(step / 2), Used: *p.Avg}
+		if i < len(totals) && totals[i].Last != nil {
+			s.Total = *totals[i].Last
+		}
+		samples = append(samples, s)
+	}
+	return samples, nil
+}
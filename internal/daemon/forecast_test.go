@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/history"
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+func TestUsageHistory(t *testing.T) {
+	buf := history.New(100, time.Hour)
+	base := time.Now().Add(-30 * time.Minute).Truncate(time.Minute)
+
+	for i := 0; i < 20; i++ {
+		buf.Add(&metrics.SystemMetrics{
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+			Disk: &metrics.DiskMetrics{Filesystems: []metrics.FilesystemMetrics{
+				{Mountpoint: "/data", Total: 1000, Used: uint64(100 + i*10)},
+			}},
+		})
+	}
+
+	u := usageHistory{querier: &history.Fallback{Recent: buf}}
+	samples, err := u.Usage("/data", base, base.Add(30*time.Minute), time.Minute)
+	if err != nil {
+		t.Fatalf("Usage() failed: %v", err)
+	}
+	if len(samples) != 20 {
+		t.Fatalf("Expected 20 samples (empty steps skipped), got %d", len(samples))
+	}
+	if samples[0].Used != 100 || samples[19].Used != 290 || samples[19].Total != 1000 {
+		t.Errorf("Unexpected samples: first %+v, last %+v", samples[0], samples[19])
+	}
+
+	// Bilinmeyen mountpoint için örnek dönmemeli
+	if samples, err := u.Usage("/missing", base, base.Add(30*time.Minute), time.Minute); err != nil || len(samples) != 0 {
+		t.Errorf("Expected no samples for unknown mountpoint, got %v %v", samples, err)
+	}
+}
@@ -0,0 +1,369 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+const (
+	// watchInterval config dosyasının değişiklik için kontrol edilme aralığı
+	watchInterval = 2 * time.Second
+
+	// dashboardStopTimeout reload ile kapatılan dashboard'un istekleri bitirmesi için beklenen süre
+	dashboardStopTimeout = 10 * time.Second
+)
+
+// configDiff iki config arasında değişen bölümler
+type configDiff struct {
+	Logging     bool
+	Interval    bool
+	Collector   bool // Collector'ın yeniden kurulmasını gerektiren metrik ayarları
+	Rules       bool
+	Channels    bool
+	Maintenance bool
+	Dashboard   bool     // enabled, host, port veya admin token
+	Restart     []string // Canlı uygulanamayan, yeniden başlatma gerektiren bölümler
+}
+
+// diffConfig çalışan config ile yeni config'i karşılaştırır
+func diffConfig(old, new *config.Config) configDiff {
+	diff := configDiff{
+		Logging:     !reflect.DeepEqual(old.Logging, new.Logging),
+		Interval:    old.Metrics.Interval != new.Metrics.Interval,
+		Rules:       !reflect.DeepEqual(old.Alerts.Rules, new.Alerts.Rules),
+		Channels:    !reflect.DeepEqual(old.Alerts.Channels, new.Alerts.Channels),
+		Maintenance: !reflect.DeepEqual(old.Alerts.Maintenance, new.Alerts.Maintenance),
+		Dashboard:   old.Dashboard != new.Dashboard,
+	}
+
+	// Aralık sadece kaynak zaman aşımlarının varsayılanı olarak collector'ı etkiler
+	oldMetrics, newMetrics := old.Metrics, new.Metrics
+	oldMetrics.Interval, newMetrics.Interval = 0, 0
+	diff.Collector = !reflect.DeepEqual(oldMetrics, newMetrics) ||
+		(diff.Interval && len(new.Metrics.Sources) > 0)
+
+	if !reflect.DeepEqual(old.Storage, new.Storage) {
+		diff.Restart = append(diff.Restart, "storage")
+	}
+	if old.History != new.History {
+		diff.Restart = append(diff.Restart, "history")
+	}
+	if old.Alerts.Enabled != new.Alerts.Enabled {
+		diff.Restart = append(diff.Restart, "alerts.enabled")
+	}
+	if old.Alerts.SilencesFile != new.Alerts.SilencesFile {
+		diff.Restart = append(diff.Restart, "alerts.silences_file")
+	}
+	if old.Daemon.WatchConfig != new.Daemon.WatchConfig {
+		diff.Restart = append(diff.Restart, "daemon.watch_config")
+	}
+	return diff
+}
+
+// takeAlerts alarm kuralı, kanal ve bakım penceresi değişikliklerini uygulanmış
+// listesinden çıkarır ve isimlerini döndürür (alarm motoru kapalıyken kullanılır)
+func (c *configDiff) takeAlerts() []string {
+	var names []string
+	for _, f := range []struct {
+		changed *bool
+		name    string
+	}{{&c.Rules, "alerts.rules"}, {&c.Channels, "alerts.channels"}, {&c.Maintenance, "alerts.maintenance"}} {
+		if *f.changed {
+			names = append(names, f.name)
+			*f.changed = false
+		}
+	}
+	return names
+}
+
+// Changes uygulanan değişikliklerin isimlerini döndürür
+func (c configDiff) Changes() []string {
+	var changes []string
+	add := func(changed bool, name string) {
+		if changed {
+			changes = append(changes, name)
+		}
+	}
+	add(c.Logging, "logging")
+	add(c.Interval, "metrics.interval")
+	add(c.Collector, "metrics")
+	add(c.Rules, "alerts.rules")
+	add(c.Channels, "alerts.channels")
+	add(c.Maintenance, "alerts.maintenance")
+	add(c.Dashboard, "dashboard")
+	return changes
+}
+
+// Reload yeni config'i doğrular ve çalışan daemon'a canlı olarak uygular.
+// Config geçersizse veya bir bileşen yeni ayarlarla başlatılamazsa hiçbir
+// değişiklik uygulanmaz ve eski config korunur. Uygulanan değişiklikleri döndürür;
+// yeniden başlatma gerektiren bölümler "(yeniden başlatma gerekli)", alarm motoru
+// kapalıyken etkisiz kalan alarm ayarları "(alarmlar kapalı, uygulanmadı)" ile işaretlenir.
+func (d *Daemon) Reload(newCfg *config.Config) ([]string, error) {
+	if err := newCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("config geçersiz: %w", err)
+	}
+
+	d.reloadMu.Lock()
+	defer d.reloadMu.Unlock()
+
+	// Start/Stop sürerken beklemek dashboard kapanışını kilitleyebilir
+	if !d.mu.TryRLock() {
+		return nil, errors.New("daemon başlatılıyor veya durduruluyor")
+	}
+	defer d.mu.RUnlock()
+	if !d.running {
+		return nil, errors.New("daemon çalışmıyor")
+	}
+
+	d.stateMu.RLock()
+	old := d.config
+	d.stateMu.RUnlock()
+
+	diff := diffConfig(old, newCfg)
+
+	// Alarm motoru kapalıyken alarm ayarları config'e yazılır ama uygulanmaz
+	var inactive []string
+	if d.alerts == nil {
+		inactive = diff.takeAlerts()
+	}
+
+	// Yeniden başlatma gerektiren bölümler çalışan değerleriyle kalır
+	applied := *newCfg
+	applied.Storage = old.Storage
+	applied.History = old.History
+	applied.Alerts.Enabled = old.Alerts.Enabled
+	applied.Alerts.SilencesFile = old.Alerts.SilencesFile
+	applied.Daemon.WatchConfig = old.Daemon.WatchConfig
+
+	// Başarısız olabilecek adımlar önce yapılır; hata olursa geri alınır
+	var undo []func()
+	fail := func(err error) ([]string, error) {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+		return nil, err
+	}
+
+	if diff.Logging {
+		if err := logger.Configure(applied.Logging.Options()); err != nil {
+			return fail(fmt.Errorf("logger yapılandırılamadı: %w", err))
+		}
+		undo = append(undo, func() { logger.Configure(old.Logging.Options()) })
+	}
+
+	var collector *metrics.Collector
+	if diff.Collector {
+		collector = d.newCollector(&applied)
+		if err := collector.Start(); err != nil {
+			return fail(fmt.Errorf("metrics collector başlatılamadı: %w", err))
+		}
+		undo = append(undo, collector.Stop)
+	}
+
+	if diff.Channels && d.notifier != nil {
+		if err := d.notifier.Update(applied.Alerts.Channels); err != nil {
+			return fail(err)
+		}
+		undo = append(undo, func() { d.notifier.Update(old.Alerts.Channels) })
+	}
+
+	if diff.Dashboard {
+		if err := d.reloadDashboard(&applied); err != nil {
+			return fail(err)
+		}
+	}
+
+	// Buradan sonraki adımlar başarısız olamaz
+	d.stateMu.Lock()
+	oldCollector := d.metricsCol
+	if collector != nil {
+		d.metricsCol = collector
+	}
+	d.config = &applied
+	d.stateMu.Unlock()
+
+	if collector != nil {
+		oldCollector.Stop()
+	}
+	if diff.Interval {
+		select {
+		case d.reloadChan <- struct{}{}:
+		default:
+		}
+	}
+	if d.alerts != nil && diff.Rules {
+		d.alerts.SetRules(applied.Alerts.Rules)
+	}
+	if d.silencer != nil && diff.Maintenance {
+		if err := d.silencer.SetWindows(applied.Alerts.Maintenance); err != nil {
+			logger.GetLogger().Errorf("Bakım pencereleri güncellenemedi: %v", err)
+		}
+	}
+
+	changes := diff.Changes()
+	for _, name := range inactive {
+		changes = append(changes, name+" (alarmlar kapalı, uygulanmadı)")
+	}
+	for _, name := range diff.Restart {
+		changes = append(changes, name+" (yeniden başlatma gerekli)")
+	}
+	return changes, nil
+}
+
+// reloadDashboard dashboard'u yeni ayarlara göre başlatır, taşır veya durdurur.
+// Hata durumunda çalışan dashboard değişmez.
+func (d *Daemon) reloadDashboard(new *config.Config) error {
+	switch {
+	case new.Dashboard.Enabled && d.dashboardSrv == nil:
+		srv := d.newDashboard(new)
+		if err := srv.Start(); err != nil {
+			return err
+		}
+		d.dashboardSrv = srv
+
+	case !new.Dashboard.Enabled && d.dashboardSrv != nil:
+		// İstek içinden çağrılmış olabilir; kapanış o isteği beklememeli
+		srv := d.dashboardSrv
+		d.dashboardSrv = nil
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dashboardStopTimeout)
+			defer cancel()
+			if err := srv.Stop(ctx); err != nil {
+				logger.GetLogger().Errorf("Dashboard server durdurulurken hata: %v", err)
+			}
+		}()
+
+	case d.dashboardSrv != nil:
+		if err := d.dashboardSrv.Rebind(new.Dashboard.Host, new.Dashboard.Port); err != nil {
+			return err
+		}
+		d.dashboardSrv.SetAdminToken(new.Dashboard.AdminToken)
+	}
+	return nil
+}
+
+// SetConfigLoader SIGHUP, admin API ve dosya izleyici ile yapılan yeniden
+// yüklemelerde kullanılacak config dosyasını ve yükleme fonksiyonunu ayarlar.
+// Start'tan önce çağrılmalıdır.
+func (d *Daemon) SetConfigLoader(path string, load func() (*config.Config, error)) {
+	d.configPath = path
+	d.loadConfig = load
+	if d.dashboardSrv != nil {
+		d.dashboardSrv.SetReloader(d.reloadFromAPI)
+	}
+}
+
+// SetVersion dashboard health endpoint'inde gösterilecek sürümü ayarlar.
+// Start'tan önce çağrılmalıdır.
+func (d *Daemon) SetVersion(version string) {
+	d.version = version
+	if d.dashboardSrv != nil {
+		d.dashboardSrv.SetVersion(version)
+	}
+}
+
+// ReloadFromSource config dosyasını yeniden okuyup uygular ve sonucu loglar.
+// trigger logda yeniden yüklemenin nedenini belirtir (sighup, api, file).
+func (d *Daemon) ReloadFromSource(trigger string) ([]string, error) {
+	log := logger.GetLogger()
+
+	if d.loadConfig == nil {
+		return nil, errors.New("config kaynağı ayarlanmamış")
+	}
+
+	cfg, err := d.loadConfig()
+	if err == nil {
+		var changes []string
+		changes, err = d.Reload(cfg)
+		if err == nil {
+			if len(changes) == 0 {
+				log.Infof("Config yeniden yüklendi (%s): değişiklik yok", trigger)
+			} else {
+				log.Infof("Config yeniden yüklendi (%s): %s", trigger, strings.Join(changes, ", "))
+			}
+			return changes, nil
+		}
+	}
+
+	log.Errorf("Config yeniden yüklenemedi (%s), mevcut config korunuyor: %v", trigger, err)
+	return nil, err
+}
+
+// reloadFromAPI /api/admin/reload isteğiyle yeniden yükler
+func (d *Daemon) reloadFromAPI() ([]string, error) {
+	return d.ReloadFromSource("api")
+}
+
+// watchConfig config dosyasını periyodik olarak kontrol eder ve değiştiğinde yeniden yükler
+func (d *Daemon) watchConfig(ctx context.Context) {
+	defer d.wg.Done()
+
+	log := logger.GetLogger()
+	log.Infof("Config dosyası izleniyor: %s", d.configPath)
+
+	last, _ := d.configFingerprint()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			current, err := d.configFingerprint()
+			if err != nil {
+				// Editörler dosyayı silip yeniden yazabilir; bir sonraki kontrolde tekrar denenir
+				continue
+			}
+			if current == last {
+				continue
+			}
+			last = current
+			if _, err := d.ReloadFromSource("file"); err == nil {
+				// Yeni config başka parçalar okumuş olabilir
+				last, _ = d.configFingerprint()
+			}
+		case <-d.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// configFingerprint ana config dosyasının, parça dizininin ve okunan parçaların
+// boyut ve değişiklik zamanlarından oluşan özeti döndürür. Dizin zamanı parça
+// eklendiğinde veya silindiğinde değişir; silinen bir parça veya dizin de özete
+// "missing" olarak yazılır. Yalnızca ana config dosyası okunamazsa hata döner.
+func (d *Daemon) configFingerprint() (string, error) {
+	d.stateMu.RLock()
+	cfg := d.config
+	d.stateMu.RUnlock()
+
+	paths := append([]string{d.configPath}, cfg.Files()...)
+	if cfg.Include != "" {
+		paths = append(paths, config.IncludeDir(d.configPath, cfg.Include))
+	}
+
+	var b strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			if path != d.configPath && os.IsNotExist(err) {
+				fmt.Fprintf(&b, "%s:missing;", path)
+				continue
+			}
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
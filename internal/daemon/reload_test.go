@@ -0,0 +1,172 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+)
+
+// testConfig dosya ve port kullanmayan daemon config'i
+func testConfig() *config.Config {
+	cfg := config.Default()
+	cfg.Dashboard.Enabled = false
+	cfg.Storage.Enabled = false
+	cfg.Alerts.SilencesFile = ""
+	return cfg
+}
+
+func TestDiffConfig(t *testing.T) {
+	old := testConfig()
+
+	same := testConfig()
+	if changes := diffConfig(old, same).Changes(); len(changes) != 0 {
+		t.Errorf("Expected no changes for identical configs, got %v", changes)
+	}
+
+	changed := testConfig()
+	changed.Metrics.Interval = 10
+	changed.Logging.Level = "debug"
+	changed.Dashboard.Port = 9090
+	changed.Storage.Retention = 3600
+	diff := diffConfig(old, changed)
+
+	if !diff.Interval || !diff.Logging || !diff.Dashboard {
+		t.Errorf("Expected interval, logging and dashboard changes, got %+v", diff)
+	}
+	if diff.Collector {
+		t.Error("Interval-only metrics change should not rebuild the collector")
+	}
+	if len(diff.Restart) != 1 || diff.Restart[0] != "storage" {
+		t.Errorf("Expected storage to require restart, got %v", diff.Restart)
+	}
+
+	changed.Metrics.EnableCPU = !old.Metrics.EnableCPU
+	if !diffConfig(old, changed).Collector {
+		t.Error("Expected collector rebuild for enabled subsystem change")
+	}
+}
+
+func TestReload(t *testing.T) {
+	cfg := testConfig()
+	d := NewWithConfig(cfg)
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Stop(context.Background())
+
+	// Geçersiz config reddedilmeli ve çalışan config değişmemeli
+	invalid := testConfig()
+	invalid.Metrics.Interval = 0
+	if _, err := d.Reload(invalid); err == nil {
+		t.Fatal("Expected invalid config to be rejected")
+	}
+	if d.config != cfg {
+		t.Error("Expected running config to be kept after rejected reload")
+	}
+
+	updated := testConfig()
+	updated.Metrics.Interval = cfg.Metrics.Interval + 1
+	updated.Metrics.EnableProcesses = !cfg.Metrics.EnableProcesses
+	updated.Alerts.Rules = updated.Alerts.Rules[:1]
+	updated.Storage.Enabled = true
+	oldCollector := d.metricsCol
+
+	changes, err := d.Reload(updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined := strings.Join(changes, ",")
+	for _, want := range []string{"metrics.interval", "metrics", "alerts.rules", "storage (yeniden başlatma gerekli)"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected change %q, got %v", want, changes)
+		}
+	}
+	if d.metricsCol == oldCollector {
+		t.Error("Expected collector to be rebuilt")
+	}
+	if d.interval() != time.Duration(updated.Metrics.Interval)*time.Second {
+		t.Errorf("Expected interval %d, got %s", updated.Metrics.Interval, d.interval())
+	}
+	// Yeniden başlatma gerektiren bölümler çalışan değerleriyle kalmalı
+	if d.config.Storage.Enabled {
+		t.Error("Expected storage to keep running value until restart")
+	}
+}
+
+func TestReloadAlertsDisabled(t *testing.T) {
+	cfg := testConfig()
+	cfg.Alerts.Enabled = false
+	d := NewWithConfig(cfg)
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Stop(context.Background())
+
+	updated := testConfig()
+	updated.Alerts.Enabled = false
+	updated.Alerts.Rules = updated.Alerts.Rules[:1]
+
+	changes, err := d.Reload(updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Kural değişikliği uygulanmış gibi raporlanmamalı
+	if len(changes) != 1 || changes[0] != "alerts.rules (alarmlar kapalı, uygulanmadı)" {
+		t.Errorf("Expected rules change to be reported as inactive, got %v", changes)
+	}
+}
+
+func TestConfigFingerprintMissingFragment(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	fragment := filepath.Join(dir, "conf.d", "10-web.yaml")
+	if err := os.MkdirAll(filepath.Dir(fragment), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(`{"include": "conf.d"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fragment, []byte("dashboard:\n  port: 9001\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewWithConfig(cfg)
+	d.SetConfigLoader(configPath, func() (*config.Config, error) { return config.Load(configPath) })
+
+	before, err := d.configFingerprint()
+	if err != nil {
+		t.Fatalf("configFingerprint() failed: %v", err)
+	}
+
+	// Silinen parça hata değil değişiklik olarak görülmeli, aksi halde izleyici takılı kalır
+	if err := os.Remove(fragment); err != nil {
+		t.Fatal(err)
+	}
+	after, err := d.configFingerprint()
+	if err != nil {
+		t.Fatalf("configFingerprint() after removing fragment failed: %v", err)
+	}
+	if after == before {
+		t.Error("Expected fingerprint to change after removing a fragment")
+	}
+	if !strings.Contains(after, fragment+":missing;") {
+		t.Errorf("Expected fragment to be marked missing, got %q", after)
+	}
+
+	// Ana config dosyası silinirse hata dönmeli
+	if err := os.Remove(configPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.configFingerprint(); err == nil {
+		t.Error("Expected error when main config file is missing")
+	}
+}
@@ -0,0 +1,47 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Reloader config dosyasını yeniden yükleyip uygular; uygulanan değişiklikleri döndürür
+type Reloader func() ([]string, error)
+
+// SetReloader /api/admin/reload endpoint'inin kullanacağı yeniden yükleme fonksiyonunu ayarlar
+func (s *Server) SetReloader(r Reloader) {
+	s.reloader = r
+}
+
+// handleReload config dosyasını yeniden yükler.
+//
+//	POST /api/admin/reload
+//
+// Config geçersizse eski config korunur ve hata 422 ile döner. İstek
+// guardMutation kontrolünden geçer; gövde gönderilmese de Content-Type
+// application/json olmalıdır.
+func (s *Server) handleReload(c *gin.Context) {
+	if s.reloader == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Config yeniden yükleme desteklenmiyor",
+		})
+		return
+	}
+
+	changes, err := s.reloader()
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if changes == nil {
+		changes = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"reloaded": true,
+		"changes":  changes,
+	})
+}
@@ -0,0 +1,88 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+	"github.com/karsterr/syswatch-daemon/internal/notify"
+)
+
+// SetAlerts /api/alerts endpoint'inin kullanacağı alarm motorunu ayarlar (Start'tan önce çağrılmalı)
+func (s *Server) SetAlerts(e *alert.Engine) {
+	s.alerts = e
+}
+
+// alertResponse alarmı varsa onu bastıran susturmayla birlikte döndürür
+type alertResponse struct {
+	alert.Alert
+	SilencedBy string `json:"silenced_by,omitempty"` // Susturma ID'si veya maintenance:<isim>
+}
+
+// handleAlerts aktif ve yakın zamanda kapanmış alarmları döndürür.
+//
+//	GET /api/alerts?state=firing
+func (s *Server) handleAlerts(c *gin.Context) {
+	if s.alerts == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Alarm motoru devre dışı",
+		})
+		return
+	}
+
+	state := alert.State(c.Query("state"))
+	alerts := make([]alertResponse, 0)
+	counts := make(map[alert.State]int)
+	for _, a := range s.alerts.Alerts() {
+		counts[a.State]++
+		if state == "" || a.State == state {
+			alerts = append(alerts, alertResponse{Alert: a, SilencedBy: s.silencedBy(a)})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"alerts":   alerts,
+		"firing":   counts[alert.StateFiring],
+		"pending":  counts[alert.StatePending],
+		"resolved": counts[alert.StateResolved],
+	})
+}
+
+// SetNotifier /api/alerts/notifications endpoint'inin kullanacağı bildirim dağıtıcısını ayarlar
+func (s *Server) SetNotifier(n *notify.Notifier) {
+	s.notifier = n
+}
+
+// handleNotifications son bildirim teslimat denemelerini yeniden eskiye döndürür.
+//
+//	GET /api/alerts/notifications?channel=ops-webhook&failed=true
+func (s *Server) handleNotifications(c *gin.Context) {
+	if s.notifier == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Bildirim kanalı yapılandırılmamış",
+		})
+		return
+	}
+
+	channel := c.Query("channel")
+	failedOnly := c.Query("failed") == "true"
+
+	deliveries := make([]notify.Delivery, 0)
+	failed := 0
+	for _, d := range s.notifier.Deliveries() {
+		if channel != "" && d.Channel != channel {
+			continue
+		}
+		if !d.Success {
+			failed++
+		} else if failedOnly {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": deliveries,
+		"failed":        failed,
+	})
+}
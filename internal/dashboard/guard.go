@@ -0,0 +1,67 @@
+package dashboard
+
+import (
+	"crypto/subtle"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetAdminToken durum değiştiren endpoint'lerde (susturma, reload) istenecek token'ı
+// ayarlar. Boşsa token istenmez; istekler yine de JSON ve aynı origin kontrolünden geçer.
+func (s *Server) SetAdminToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.adminToken = token
+}
+
+// guardMutation durum değiştiren isteklerin başka bir siteden tarayıcı üzerinden
+// (CSRF) tetiklenmesini engeller:
+//   - admin token ayarlıysa "Authorization: Bearer <token>" zorunludur
+//   - POST istekleri "Content-Type: application/json" göndermelidir; başka sitelerin
+//     form ve text/plain istekleri bu header'ı preflight olmadan gönderemez
+//   - Origin (yoksa Referer) header'ı varsa isteğin yapıldığı host ile aynı olmalıdır
+//
+// Origin ve Referer göndermeyen (tarayıcı dışı) istemciler kabul edilir.
+func (s *Server) guardMutation(c *gin.Context) {
+	s.mu.Lock()
+	token := s.adminToken
+	s.mu.Unlock()
+
+	if token != "" {
+		given, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Geçerli admin token gerekli (Authorization: Bearer <token>)",
+			})
+			return
+		}
+	}
+
+	if c.Request.Method == http.MethodPost {
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+				"error": "Content-Type application/json olmalı",
+			})
+			return
+		}
+	}
+
+	origin := c.GetHeader("Origin")
+	if origin == "" {
+		origin = c.GetHeader("Referer")
+	}
+	if origin != "" {
+		if _, err := sameHost(origin, c.Request); err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+	}
+
+	c.Next()
+}
@@ -0,0 +1,67 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+func TestGuardMutation(t *testing.T) {
+	srv := NewServer(metrics.NewStore(), "localhost", 8080)
+	srv.setupRoutes()
+
+	// Guard'ı geçen istekler silencer olmadığı için 404 alır
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		headers map[string]string
+		want    int
+	}{
+		{"cross-site text/plain", "POST", "/api/silences", map[string]string{"Content-Type": "text/plain", "Origin": "http://evil.example"}, http.StatusUnsupportedMediaType},
+		{"form post", "POST", "/api/silences", map[string]string{"Content-Type": "application/x-www-form-urlencoded"}, http.StatusUnsupportedMediaType},
+		{"cross-site json", "POST", "/api/silences", map[string]string{"Content-Type": "application/json", "Origin": "http://evil.example"}, http.StatusForbidden},
+		{"cross-site referer", "DELETE", "/api/silences/x", map[string]string{"Referer": "http://evil.example/page"}, http.StatusForbidden},
+		{"same origin", "POST", "/api/silences", map[string]string{"Content-Type": "application/json; charset=utf-8", "Origin": "http://localhost:8080"}, http.StatusNotFound},
+		{"non-browser client", "DELETE", "/api/silences/x", nil, http.StatusNotFound},
+		{"cross-site reload", "POST", "/api/admin/reload", map[string]string{"Content-Type": "text/plain", "Origin": "http://evil.example"}, http.StatusUnsupportedMediaType},
+		{"reload", "POST", "/api/admin/reload", map[string]string{"Content-Type": "application/json"}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "http://localhost:8080"+tt.path, strings.NewReader("{}"))
+		for k, v := range tt.headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d (%s)", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Token ayarlıysa aynı origin'den gelen istekler de token göstermeli
+	srv.SetAdminToken("s3cret")
+	for _, auth := range []string{"", "Bearer wrong", "s3cret"} {
+		req := httptest.NewRequest("DELETE", "http://localhost:8080/api/silences/x", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401 for Authorization %q, got %d", auth, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest("DELETE", "http://localhost:8080/api/silences/x", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected valid token to pass guard, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karsterr/syswatch-daemon/internal/history"
+)
+
+// Geçmiş sorgusu varsayılanları
+const (
+	defaultHistoryRange  = 15 * time.Minute
+	defaultHistoryPoints = 100
+)
+
+// SetHistory /api/history endpoint'inin kullanacağı kaynağı ayarlar (Start'tan önce çağrılmalı)
+func (s *Server) SetHistory(h history.Querier) {
+	s.history = h
+}
+
+// handleHistory geçmiş metrikleri adım adım özetlenmiş seriler olarak döndürür.
+//
+//	GET /api/history?fields=cpu.usage,memory.usage&from=-1h&to=now&step=1m
+//
+// from/to RFC3339, unix saniye, "now" veya şimdiye göre negatif süre (-10m) olabilir.
+// step Go süre formatında (30s, 5m) veya saniye cinsinden verilir.
+func (s *Server) handleHistory(c *gin.Context) {
+	if s.history == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Metrik geçmişi devre dışı",
+		})
+		return
+	}
+
+	q, err := parseHistoryQuery(c, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Geçersiz sorgu",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := s.history.Query(q)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Geçmiş sorgusu başarısız",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// handleHistoryFields sorgulanabilir alan yollarını en son snapshot'tan listeler
+func (s *Server) handleHistoryFields(c *gin.Context) {
+	snapshot, ok := s.latestSnapshot(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"fields": snapshot.Fields(),
+	})
+}
+
+// parseHistoryQuery HTTP parametrelerinden geçmiş sorgusu oluşturur
+func parseHistoryQuery(c *gin.Context, now time.Time) (history.Query, error) {
+	var q history.Query
+
+	// fields=a,b veya field=a&field=b
+	for _, raw := range append(c.QueryArray("fields"), c.QueryArray("field")...) {
+		for _, f := range strings.Split(raw, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				q.Fields = append(q.Fields, f)
+			}
+		}
+	}
+	if len(q.Fields) == 0 {
+		return q, fmt.Errorf("fields parametresi gerekli (alanlar için /api/history/fields)")
+	}
+
+	var err error
+	q.To = now
+	if raw := c.Query("to"); raw != "" {
+		if q.To, err = parseTime(raw, now); err != nil {
+			return q, fmt.Errorf("to: %w", err)
+		}
+	}
+
+	q.From = q.To.Add(-defaultHistoryRange)
+	if raw := c.Query("from"); raw != "" {
+		if q.From, err = parseTime(raw, now); err != nil {
+			return q, fmt.Errorf("from: %w", err)
+		}
+	}
+
+	if raw := c.Query("step"); raw != "" {
+		if q.Step, err = parseStep(raw); err != nil {
+			return q, fmt.Errorf("step: %w", err)
+		}
+	} else {
+		q.Step = (q.To.Sub(q.From) / defaultHistoryPoints).Truncate(time.Second)
+		if q.Step < time.Second {
+			q.Step = time.Second
+		}
+	}
+
+	return q, nil
+}
+
+// parseTime RFC3339, unix saniye, "now" veya negatif göreli süre kabul eder
+func parseTime(raw string, now time.Time) (time.Time, error) {
+	if raw == "now" {
+		return now, nil
+	}
+	if strings.HasPrefix(raw, "-") {
+		d, err := time.ParseDuration(raw[1:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(-d), nil
+	}
+	if secs, err := strconv.ParseFloat(raw, 64); err == nil {
+		return time.Unix(0, int64(secs*float64(time.Second))), nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// parseStep Go süre formatı veya saniye cinsinden adım kabul eder
+func parseStep(raw string) (time.Duration, error) {
+	if secs, err := strconv.ParseFloat(raw, 64); err == nil {
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+	return time.ParseDuration(raw)
+}
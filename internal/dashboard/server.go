@@ -2,25 +2,51 @@ package dashboard
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+	"github.com/karsterr/syswatch-daemon/internal/exposition"
+	"github.com/karsterr/syswatch-daemon/internal/history"
 	"github.com/karsterr/syswatch-daemon/internal/logger"
 	"github.com/karsterr/syswatch-daemon/internal/metrics"
+	"github.com/karsterr/syswatch-daemon/internal/notify"
+	"github.com/karsterr/syswatch-daemon/internal/silence"
 )
 
+// rebindDrainTimeout adres değişikliğinde eski sunucudaki isteklerin tamamlanması için beklenen süre
+const rebindDrainTimeout = 10 * time.Second
+
 // Server web dashboard HTTP sunucusu
 type Server struct {
+	mu         sync.Mutex // server, host ve port'u korur (Rebind ile değişebilir)
 	server     *http.Server
+	listener   net.Listener
 	router     *gin.Engine
-	collector  *metrics.Collector
+	store      *metrics.Store
+	history    history.Querier
+	alerts     *alert.Engine
+	notifier   *notify.Notifier
+	silencer   *silence.Silencer
+	reloader   Reloader
+	adminToken string // Değiştiren isteklerde istenen token (boşsa istenmez); mu ile korunur
+	version    string // Health endpoint'inde gösterilen sürüm
+	host       string
 	port       int
+	streamDone chan struct{} // Stop'ta kapanır; açık akışları sonlandırır
+	stopOnce   sync.Once     // Stop birden fazla çağrılırsa streamDone bir kez kapatılır
 }
 
-// NewServer yeni dashboard server oluşturur
-func NewServer(collector *metrics.Collector, port int) *Server {
+// NewServer yeni dashboard server oluşturur.
+// Server metrik toplamaz; daemon döngüsünün store'a yayınladığı son snapshot'ı sunar.
+// host boşsa tüm arayüzler dinlenir.
+func NewServer(store *metrics.Store, host string, port int) *Server {
 	// Production modda gin loglarını kapat
 	gin.SetMode(gin.ReleaseMode)
 	
@@ -28,49 +54,144 @@ func NewServer(collector *metrics.Collector, port int) *Server {
 	router.Use(gin.Recovery())
 	
 	return &Server{
-		router:    router,
-		collector: collector,
-		port:      port,
+		router:     router,
+		store:      store,
+		host:       host,
+		port:       port,
+		version:    "dev",
+		streamDone: make(chan struct{}),
 	}
 }
 
+// SetVersion health endpoint'inde gösterilecek sürümü ayarlar. Start'tan önce çağrılmalıdır.
+func (s *Server) SetVersion(version string) {
+	s.version = version
+}
+
 // Start dashboard sunucusunu başlatır
 func (s *Server) Start() error {
-	log := logger.GetLogger()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	
 	// Routes'ları tanımla
 	s.setupRoutes()
 	
-	// HTTP server'ı oluştur
-	s.server = &http.Server{
-		Addr:    fmt.Sprintf(":%d", s.port),
+	// Port'u senkron olarak dinle ki bind hataları Start'tan dönsün
+	listener, err := listen(s.host, s.port)
+	if err != nil {
+		return err
+	}
+	
+	s.serve(listener)
+	return nil
+}
+
+// Rebind sunucuyu yeni adrese taşır. Yeni adres dinlenmeye başlandıktan sonra eski
+// sunucudaki istekler arka planda tamamlanır. Adres dinlenemezse eski adreste devam edilir.
+func (s *Server) Rebind(host string, port int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if s.server == nil || (host == s.host && port == s.port) {
+		return nil
+	}
+	
+	oldServer, oldListener := s.server, s.listener
+	oldHost, oldPort := s.host, s.port
+	
+	listener, err := listen(host, port)
+	if err != nil && port == oldPort {
+		// Aynı port farklı host ile (ör. localhost -> 0.0.0.0) çakışabilir; önce eski dinleyici kapatılır
+		oldListener.Close()
+		listener, err = listen(host, port)
+		if err != nil {
+			if prev, rerr := listen(oldHost, oldPort); rerr == nil {
+				s.serve(prev)
+			} else {
+				logger.GetLogger().Errorf("Dashboard eski adreste yeniden başlatılamadı: %v", rerr)
+			}
+			go drain(oldServer)
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	
+	oldListener.Close()
+	s.host, s.port = host, port
+	s.serve(listener)
+	go drain(oldServer)
+	return nil
+}
+
+// serve listener'ı yeni bir http.Server ile arka planda dinler (çağıran mu'yu tutmalı)
+func (s *Server) serve(listener net.Listener) {
+	log := logger.GetLogger()
+	
+	srv := &http.Server{
+		Addr:    listener.Addr().String(),
 		Handler: s.router,
 	}
+	s.server = srv
+	s.listener = listener
 	
-	log.Infof("Web dashboard başlatılıyor: http://localhost:%d", s.port)
+	log.Infof("Web dashboard başlatılıyor: http://%s", displayAddr(s.host, s.port))
 	
 	// Server'ı background'da başlat
 	go func() {
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		err := srv.Serve(listener)
+		if err != nil && err != http.ErrServerClosed && !errors.Is(err, net.ErrClosed) {
 			log.Errorf("Dashboard server hatası: %v", err)
 		}
 	}()
-	
-	return nil
+}
+
+// listen dashboard adresini dinler; host boşsa tüm arayüzler kullanılır
+func listen(host string, port int) (net.Listener, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("dashboard portu dinlenemedi: %w", err)
+	}
+	return listener, nil
+}
+
+// displayAddr loglarda gösterilecek adresi döndürür
+func displayAddr(host string, port int) string {
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// drain taşınan eski sunucudaki isteklerin bitmesini bekler; süre dolarsa bağlantılar kapatılır
+func drain(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), rebindDrainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		srv.Close()
+	}
 }
 
 // Stop dashboard sunucusunu durdurur
 func (s *Server) Stop(ctx context.Context) error {
 	log := logger.GetLogger()
 	
-	if s.server == nil {
+	s.mu.Lock()
+	srv := s.server
+	s.mu.Unlock()
+	
+	if srv == nil {
 		return nil
 	}
 	
 	log.Info("Web dashboard kapatılıyor...")
 	
+	// Uzun ömürlü akış bağlantıları Shutdown'ı bekletmesin
+	s.stopOnce.Do(func() { close(s.streamDone) })
+	
 	// Graceful shutdown
-	if err := s.server.Shutdown(ctx); err != nil {
+	if err := srv.Shutdown(ctx); err != nil {
 		log.Errorf("Dashboard shutdown hatası: %v", err)
 		return err
 	}
@@ -84,11 +205,26 @@ func (s *Server) setupRoutes() {
 	// Ana sayfa
 	s.router.GET("/", s.handleHome)
 	
+	// Prometheus scrape endpoint
+	s.router.GET("/metrics", s.handlePrometheus)
+	
 	// API endpoints
 	api := s.router.Group("/api")
 	{
 		api.GET("/metrics", s.handleMetrics)
 		api.GET("/health", s.handleHealth)
+		api.GET("/processes", s.handleProcesses)
+		api.GET("/history", s.handleHistory)
+		api.GET("/history/fields", s.handleHistoryFields)
+		api.GET("/alerts", s.handleAlerts)
+		api.GET("/alerts/notifications", s.handleNotifications)
+		api.GET("/silences", s.handleSilences)
+		api.POST("/silences", s.guardMutation, s.handleCreateSilence)
+		api.DELETE("/silences/:id", s.guardMutation, s.handleDeleteSilence)
+		api.GET("/maintenance", s.handleMaintenance)
+		api.GET("/stream", s.handleStream)
+		api.GET("/stream/ws", s.handleStreamWS)
+		api.POST("/admin/reload", s.guardMutation, s.handleReload)
 	}
 	
 	// Static files (CSS, JS)
@@ -170,29 +306,137 @@ func (s *Server) handleHome(c *gin.Context) {
         .memory { color: #4ECDC4; }
         .disk { color: #45B7D1; }
         .network { color: #FFA726; }
+        .watch-row {
+            padding: 4px 0;
+            text-align: left;
+        }
+        .watch-row.down { color: #FF6B6B; font-weight: bold; }
+        .alert-row {
+            padding: 4px 0;
+            text-align: left;
+        }
+        .alert-row.critical { color: #FF6B6B; font-weight: bold; }
+        .alert-row.warning { color: #FFA726; }
+        .alert-row.pending { opacity: 0.6; }
+        .forecast-warning { color: #FFA726; font-weight: bold; }
     </style>
     <script>
-        function updateMetrics() {
-            fetch('/api/metrics')
-                .then(response => response.json())
+        // formatDuration saniyeyi kısa okunabilir süreye çevirir
+        function formatDuration(seconds) {
+            if (seconds < 3600) {
+                return Math.max(1, Math.round(seconds / 60)) + ' dk';
+            }
+            if (seconds < 48 * 3600) {
+                return Math.floor(seconds / 3600) + ' sa ' + Math.round((seconds % 3600) / 60) + ' dk';
+            }
+            return Math.round(seconds / 86400) + ' gün';
+        }
+        
+        // renderDiskForecast en dolu dosya sisteminin tahmini dolma süresini gösterir;
+        // başka bir dosya sistemi daha önce dolacaksa o da belirtilir
+        function renderDiskForecast(disk) {
+            const el = document.getElementById('disk-forecast');
+            let fullest = null, soonest = null;
+            (disk.filesystems || []).forEach(function(fs) {
+                if (fs.mountpoint === disk.fullest) {
+                    fullest = fs;
+                }
+                if (fs.time_to_full_seconds != null && (!soonest || fs.time_to_full_seconds < soonest.time_to_full_seconds)) {
+                    soonest = fs;
+                }
+            });
+            
+            let text = '';
+            if (fullest && fullest.time_to_full_seconds != null) {
+                text = 'Dolma: ~' + formatDuration(fullest.time_to_full_seconds);
+            } else if (fullest && fullest.growth_bytes_per_hour != null) {
+                text = 'Büyümüyor';
+            }
+            if (soonest && soonest !== fullest) {
+                text += (text ? ' · ' : '') + soonest.mountpoint + ' ~' + formatDuration(soonest.time_to_full_seconds);
+            }
+            el.textContent = text;
+            el.className = 'metric-unit' + (soonest && soonest.time_to_full_seconds < 4 * 3600 ? ' forecast-warning' : '');
+        }
+        
+        function renderMetrics(data) {
+            // Devre dışı veya hatalı alt sistemler yanıtta yer almaz
+            if (data.cpu) {
+                document.getElementById('cpu-value').textContent = data.cpu.usage.toFixed(1);
+            }
+            if (data.memory) {
+                document.getElementById('memory-value').textContent = data.memory.usage.toFixed(1);
+            }
+            if (data.disk) {
+                // En dolu dosya sistemi gösterilir
+                document.getElementById('disk-value').textContent = data.disk.usage.toFixed(1);
+                document.getElementById('disk-mount').textContent = '% (' + data.disk.fullest + ')';
+                renderDiskForecast(data.disk);
+            }
+            if (data.network) {
+                document.getElementById('network-recv').textContent = (data.network.recv_bytes_per_sec / (1024*1024)).toFixed(2);
+                document.getElementById('network-sent').textContent = (data.network.sent_bytes_per_sec / (1024*1024)).toFixed(2);
+            }
+            if (data.watchlist) {
+                renderWatchlist(data.watchlist);
+            }
+            document.getElementById('last-update').textContent = 'Son güncelleme: ' + new Date(data.timestamp).toLocaleTimeString();
+            updateAlerts();
+        }
+        
+        // Alarmlar her snapshot'ta yeniden değerlendirildiği için akış mesajıyla birlikte yenilenir
+        function updateAlerts() {
+            fetch('/api/alerts')
+                .then(response => response.ok ? response.json() : null)
                 .then(data => {
-                    document.getElementById('cpu-value').textContent = data.cpu.usage.toFixed(1);
-                    document.getElementById('memory-value').textContent = data.memory.usage.toFixed(1);
-                    document.getElementById('disk-value').textContent = data.disk.usage.toFixed(1);
-                    document.getElementById('network-recv').textContent = (data.network.bytes_recv / (1024*1024)).toFixed(2);
-                    document.getElementById('network-sent').textContent = (data.network.bytes_sent / (1024*1024)).toFixed(2);
-                    document.getElementById('last-update').textContent = 'Son güncelleme: ' + new Date().toLocaleTimeString();
+                    if (data) {
+                        renderAlerts(data.alerts.filter(a => a.state !== 'resolved'));
+                    }
                 })
                 .catch(error => {
-                    console.error('Metrics yüklenemedi:', error);
+                    console.error('Alarmlar yüklenemedi:', error);
                 });
         }
         
-        // Sayfa yüklendiğinde ve her 5 saniyede bir güncelle
-        document.addEventListener('DOMContentLoaded', function() {
-            updateMetrics();
-            setInterval(updateMetrics, 5000);
-        });
+        function renderAlerts(alerts) {
+            const container = document.getElementById('alerts-items');
+            container.innerHTML = '';
+            alerts.forEach(a => {
+                const row = document.createElement('div');
+                row.className = 'alert-row ' + a.severity + (a.state === 'pending' ? ' pending' : '');
+                row.textContent = (a.state === 'firing' ? '🚨 ' : '⏳ ') + a.rule + ' — ' + a.summary + ' (değer: ' + a.value.toFixed(1) + ')';
+                container.appendChild(row);
+            });
+            document.getElementById('alerts-card').style.display = alerts.length > 0 ? 'block' : 'none';
+        }
+        
+        // Daemon her snapshot topladığında sunucu tarafından gönderilir;
+        // bağlantı koparsa EventSource otomatik olarak yeniden bağlanır
+        function connectStream() {
+            const source = new EventSource('/api/stream');
+            source.addEventListener('metrics', event => {
+                renderMetrics(JSON.parse(event.data));
+            });
+            source.onerror = () => {
+                document.getElementById('last-update').textContent = 'Bağlantı koptu, yeniden bağlanılıyor...';
+            };
+        }
+        
+        function renderWatchlist(watchlist) {
+            const container = document.getElementById('watchlist-items');
+            container.innerHTML = '';
+            watchlist.forEach(w => {
+                const row = document.createElement('div');
+                row.className = 'watch-row ' + (w.up ? 'up' : 'down');
+                const detail = w.up ? 'PID ' + w.pid + ', ' + w.cpu_percent.toFixed(1) + '% CPU' : 'ÇALIŞMIYOR';
+                row.textContent = (w.up ? '🟢 ' : '🔴 ') + w.name + ' — ' + detail + ' (restart: ' + w.restarts + ')';
+                container.appendChild(row);
+            });
+            document.getElementById('watchlist-card').style.display = 'block';
+        }
+        
+        // Sayfa yüklendiğinde canlı akışa bağlan
+        document.addEventListener('DOMContentLoaded', connectStream);
     </script>
 </head>
 <body>
@@ -218,7 +462,8 @@ func (s *Server) handleHome(c *gin.Context) {
             <div class="metric-card disk">
                 <div class="metric-title">💾 Disk Kullanımı</div>
                 <div class="metric-value"><span id="disk-value">--</span></div>
-                <div class="metric-unit">%</div>
+                <div class="metric-unit" id="disk-mount">%</div>
+                <div class="metric-unit" id="disk-forecast"></div>
             </div>
             
             <div class="metric-card network">
@@ -231,6 +476,16 @@ func (s *Server) handleHome(c *gin.Context) {
             </div>
         </div>
         
+        <div class="metric-card alerts" id="alerts-card" style="display: none; margin-bottom: 30px;">
+            <div class="metric-title">🚨 Aktif Alarmlar</div>
+            <div id="alerts-items"></div>
+        </div>
+        
+        <div class="metric-card watchlist" id="watchlist-card" style="display: none; margin-bottom: 30px;">
+            <div class="metric-title">👀 İzlenen Process'ler</div>
+            <div id="watchlist-items"></div>
+        </div>
+        
         <div class="last-update">
             <span class="status-indicator"></span>
             <span id="last-update">Bağlanıyor...</span>
@@ -243,26 +498,138 @@ func (s *Server) handleHome(c *gin.Context) {
 	c.String(http.StatusOK, html)
 }
 
+// metricsResponse snapshot'ı yaşı ile birlikte döndürür
+type metricsResponse struct {
+	*metrics.SystemMetrics
+	AgeSeconds float64 `json:"age_seconds"`
+}
+
+// latestSnapshot store'daki son snapshot'ı alır ve yaş header'larını yazar.
+// Henüz snapshot yoksa 503 döner ve false verir.
+func (s *Server) latestSnapshot(c *gin.Context) (*metrics.SystemMetrics, bool) {
+	snapshot := s.store.Latest()
+	if snapshot == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Henüz metrik toplanmadı",
+		})
+		return nil, false
+	}
+	
+	c.Header("X-Snapshot-Age", strconv.FormatFloat(snapshot.Age().Seconds(), 'f', 3, 64))
+	c.Header("Last-Modified", snapshot.Timestamp.UTC().Format(http.TimeFormat))
+	return snapshot, true
+}
+
 // handleMetrics API endpoint for metrics
 func (s *Server) handleMetrics(c *gin.Context) {
-	metrics, err := s.collector.CollectAll()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Metrikler alınamadı",
-			"details": err.Error(),
+	snapshot, ok := s.latestSnapshot(c)
+	if !ok {
+		return
+	}
+	
+	c.JSON(http.StatusOK, metricsResponse{
+		SystemMetrics: snapshot,
+		AgeSeconds:    snapshot.Age().Seconds(),
+	})
+}
+
+// handlePrometheus son snapshot'ı Prometheus text veya OpenMetrics formatında döndürür.
+// Format Accept header'ına göre seçilir; scrape başına toplama yapılmaz.
+func (s *Server) handlePrometheus(c *gin.Context) {
+	snapshot := s.store.Latest()
+	if snapshot == nil {
+		c.String(http.StatusServiceUnavailable, "Henüz metrik toplanmadı\n")
+		return
+	}
+	
+	format := exposition.Negotiate(c.GetHeader("Accept"))
+	c.Header("Content-Type", format.ContentType())
+	c.Status(http.StatusOK)
+	if err := exposition.Write(c.Writer, snapshot, format); err != nil {
+		logger.GetLogger().Errorf("Prometheus çıktısı yazılamadı: %v", err)
+	}
+}
+
+// handleProcesses en çok kaynak kullanan process'leri döndürür.
+// ?sort=cpu|memory|threads ile tek bir liste istenebilir.
+func (s *Server) handleProcesses(c *gin.Context) {
+	snapshot, ok := s.latestSnapshot(c)
+	if !ok {
+		return
+	}
+	
+	if snapshot.Processes == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Process collector devre dışı veya process listesi alınamadı",
+			"details": snapshot.Errors[metrics.SubsystemProcesses],
 		})
 		return
 	}
 	
-	c.JSON(http.StatusOK, metrics)
+	if sortKey := c.Query("sort"); sortKey != "" {
+		top, ok := snapshot.Processes.Top[sortKey]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Geçersiz sıralama anahtarı: %s", sortKey),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"total": snapshot.Processes.Total,
+			"sort":  sortKey,
+			"top":   top,
+			"age_seconds": snapshot.Age().Seconds(),
+		})
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"total": snapshot.Processes.Total,
+		"top":   snapshot.Processes.Top,
+		"age_seconds": snapshot.Age().Seconds(),
+	})
 }
 
-// handleHealth health check endpoint
+// handleHealth health check endpoint.
+// İzlenen process'lerden biri çalışmıyorsa veya kritik bir alarm aktifse 503 ve "degraded" döner.
 func (s *Server) handleHealth(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
+	status := http.StatusOK
+	response := gin.H{
 		"status": "healthy",
 		"timestamp": time.Now().Format(time.RFC3339),
 		"service": "syswatch-daemon",
-		"version": "0.1.0",
-	})
-}
\ No newline at end of file
+		"version": s.version,
+	}
+	
+	if snapshot := s.store.Latest(); snapshot != nil {
+		response["snapshot_age_seconds"] = snapshot.Age().Seconds()
+		if snapshot.Watchlist != nil {
+			response["watchlist"] = snapshot.Watchlist
+			for _, w := range snapshot.Watchlist {
+				if !w.Up {
+					status = http.StatusServiceUnavailable
+					response["status"] = "degraded"
+					break
+				}
+			}
+		}
+	}
+	
+	if s.alerts != nil {
+		firing := s.alerts.Firing()
+		if firing == nil {
+			firing = []alert.Alert{}
+		}
+		response["alerts"] = firing
+		for _, a := range firing {
+			// Susturulan (ör. bakımdaki) alarmlar servisi degraded yapmaz
+			if a.Severity == alert.SeverityCritical && s.silencedBy(a) == "" {
+				status = http.StatusServiceUnavailable
+				response["status"] = "degraded"
+				break
+			}
+		}
+	}
+	
+	c.JSON(status, response)
+}
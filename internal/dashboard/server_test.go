@@ -0,0 +1,43 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+func TestServerStopTwice(t *testing.T) {
+	srv := NewServer(metrics.NewStore(), "127.0.0.1", 0)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+
+	// İkinci Stop streamDone'u yeniden kapatmaya çalışıp panic olmamalı
+	if err := srv.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() failed: %v", err)
+	}
+	if err := srv.Stop(context.Background()); err != nil {
+		t.Errorf("Second Stop() failed: %v", err)
+	}
+}
+
+func TestHealthVersion(t *testing.T) {
+	srv := NewServer(metrics.NewStore(), "localhost", 8080)
+	srv.SetVersion("1.2.3")
+	srv.setupRoutes()
+
+	req := httptest.NewRequest("GET", "/api/health", nil)
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid health response: %v", err)
+	}
+	if body["version"] != "1.2.3" {
+		t.Errorf("Expected version 1.2.3, got %v", body["version"])
+	}
+}
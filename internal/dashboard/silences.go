@@ -0,0 +1,176 @@
+package dashboard
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+	"github.com/karsterr/syswatch-daemon/internal/silence"
+)
+
+// SetSilencer susturma ve bakım penceresi endpoint'lerinin kullanacağı silencer'ı ayarlar
+func (s *Server) SetSilencer(sl *silence.Silencer) {
+	s.silencer = sl
+}
+
+// silencedBy alarmı şu an bastıran susturmayı döndürür (yoksa boş string)
+func (s *Server) silencedBy(a alert.Alert) string {
+	if s.silencer == nil {
+		return ""
+	}
+	return s.silencer.Silenced(a, time.Now())
+}
+
+// createSilenceRequest POST /api/silences gövdesi.
+// Bitiş ends_at veya starts_at'tan itibaren duration ("2h", "90m") ile verilir.
+type createSilenceRequest struct {
+	Rule      string            `json:"rule"`
+	Labels    map[string]string `json:"labels"`
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    time.Time         `json:"ends_at"`
+	Duration  string            `json:"duration"`
+	CreatedBy string            `json:"created_by"`
+	Comment   string            `json:"comment"`
+}
+
+// handleSilences susturmaları döndürür.
+//
+//	GET /api/silences?all=true
+//
+// all verilmezse süresi dolmuş susturmalar listelenmez.
+func (s *Server) handleSilences(c *gin.Context) {
+	if s.silencer == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Alarm motoru devre dışı",
+		})
+		return
+	}
+
+	now := time.Now()
+	silences := s.silencer.List(c.Query("all") == "true")
+	active := 0
+	for i := range silences {
+		if silences[i].Active(now) {
+			active++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"silences": silences,
+		"active":   active,
+	})
+}
+
+// handleCreateSilence yeni susturma oluşturur.
+//
+//	POST /api/silences
+//	{"rule": "disk_full", "labels": {"instance": "/data"}, "duration": "2h",
+//	 "created_by": "ops", "comment": "disk migration"}
+//
+// İstek guardMutation kontrolünden geçer (JSON, aynı origin, varsa admin token).
+func (s *Server) handleCreateSilence(c *gin.Context) {
+	if s.silencer == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Alarm motoru devre dışı",
+		})
+		return
+	}
+
+	var req createSilenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Geçersiz susturma isteği",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	sil := silence.Silence{
+		Rule:      req.Rule,
+		Labels:    req.Labels,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		CreatedBy: req.CreatedBy,
+		Comment:   req.Comment,
+	}
+	if req.Duration != "" {
+		if !req.EndsAt.IsZero() {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "ends_at ve duration birlikte verilemez",
+			})
+			return
+		}
+		d, err := time.ParseDuration(req.Duration)
+		if err != nil || d <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Geçersiz duration",
+				"details": req.Duration,
+			})
+			return
+		}
+		if sil.StartsAt.IsZero() {
+			sil.StartsAt = time.Now()
+		}
+		sil.EndsAt = sil.StartsAt.Add(d)
+	}
+
+	created, err := s.silencer.Add(sil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Susturma oluşturulamadı",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	logger.GetLogger().Infof("Susturma oluşturuldu: %s (%s, %s - %s): %s",
+		created.ID, created.CreatedBy, created.StartsAt.Format(time.RFC3339), created.EndsAt.Format(time.RFC3339), created.Comment)
+	c.JSON(http.StatusCreated, created)
+}
+
+// handleDeleteSilence susturmayı kaldırır.
+//
+//	DELETE /api/silences/:id
+//
+// İstek guardMutation kontrolünden geçer.
+func (s *Server) handleDeleteSilence(c *gin.Context) {
+	if s.silencer == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Alarm motoru devre dışı",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	if err := s.silencer.Delete(id); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, silence.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error":   "Susturma silinemedi",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	logger.GetLogger().Infof("Susturma silindi: %s", id)
+	c.Status(http.StatusNoContent)
+}
+
+// handleMaintenance config'deki bakım pencerelerini güncel durumlarıyla döndürür
+func (s *Server) handleMaintenance(c *gin.Context) {
+	if s.silencer == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Alarm motoru devre dışı",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"windows": s.silencer.Windows(time.Now()),
+	})
+}
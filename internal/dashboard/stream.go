@@ -0,0 +1,266 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+	"golang.org/x/net/websocket"
+)
+
+// Akış ayarları
+const (
+	streamBuffer    = 4                // İstemci düşürülmeden önce bekleyebilecek snapshot sayısı
+	streamHeartbeat = 15 * time.Second // SSE bağlantısını proxy'lerde canlı tutan yorum aralığı
+)
+
+// streamWriteTimeout tek bir akış mesajının yazma zaman aşımı. Okumayı bırakan
+// istemcinin handler'ı bloklayıp Stop'u bekletmesini engeller (testlerde kısaltılır).
+var streamWriteTimeout = 10 * time.Second
+
+// streamOptions bir akış istemcisinin isteğe bağlı ayarları
+type streamOptions struct {
+	fields   []string      // Boşsa tüm snapshot gönderilir
+	interval time.Duration // İki mesaj arasındaki en kısa süre (0 = her snapshot)
+}
+
+// streamValues alan filtresi verildiğinde gönderilen mesaj
+type streamValues struct {
+	Timestamp time.Time          `json:"timestamp"`
+	Values    map[string]float64 `json:"values"`
+}
+
+// handleStream yeni snapshot'ları Server-Sent Events ile iletir.
+//
+//	GET /api/stream?fields=cpu.usage,network&interval=10s
+//
+// fields alan yolları veya önekleridir; interval istemci başına en kısa mesaj aralığıdır.
+func (s *Server) handleStream(c *gin.Context) {
+	opts, err := parseStreamOptions(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Geçersiz akış parametresi",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// http.Server'da WriteTimeout olmadığından her yazmaya ayrı süre verilir; süre
+	// dolduğunda bağlantı hata döner ve akış sonlanır
+	rc := http.NewResponseController(c.Writer)
+	send := func(msg string) error {
+		rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := c.Writer.WriteString(msg); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	write := func(data []byte) error {
+		return send(fmt.Sprintf("event: metrics\ndata: %s\n\n", data))
+	}
+	heartbeat := func() error {
+		return send(": ping\n\n")
+	}
+
+	if s.runStream(c.Request.Context().Done(), opts, write, heartbeat) {
+		// EventSource yeniden bağlanır; istemciye neden koptuğu bildirilir
+		send("event: dropped\ndata: {}\n\n")
+	}
+}
+
+// handleStreamWS yeni snapshot'ları WebSocket üzerinden iletir.
+// Parametreler /api/stream ile aynıdır; istemciden gelen mesajlar yok sayılır.
+func (s *Server) handleStreamWS(c *gin.Context) {
+	opts, err := parseStreamOptions(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Geçersiz akış parametresi",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			// İstemci bağlantıyı kapattığında akışı sonlandırmak için okuma döngüsü
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+				var discard []byte
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+			}()
+
+			write := func(data []byte) error {
+				ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+				return websocket.Message.Send(ws, string(data))
+			}
+			s.runStream(closed, opts, write, nil)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// runStream aboneliği açar ve her snapshot'ı (throttle uygulayarak) write ile gönderir.
+// İstemci geride kaldığı için düşürüldüyse true döner.
+func (s *Server) runStream(done <-chan struct{}, opts streamOptions, write func([]byte) error, heartbeat func() error) bool {
+	log := logger.GetLogger()
+	sub := s.store.Subscribe(streamBuffer)
+	defer sub.Close()
+
+	send := func(m *metrics.SystemMetrics) bool {
+		data, err := json.Marshal(opts.payload(m))
+		if err != nil {
+			log.Errorf("Akış mesajı oluşturulamadı: %v", err)
+			return true
+		}
+		return write(data) == nil
+	}
+
+	// Bağlanan istemci ilk toplamayı beklemeden son snapshot'ı alır
+	var lastSent time.Time
+	if latest := s.store.Latest(); latest != nil {
+		if !send(latest) {
+			return false
+		}
+		lastSent = time.Now()
+	}
+
+	var (
+		pending  *metrics.SystemMetrics
+		throttle <-chan time.Time
+	)
+
+	ping := time.NewTicker(streamHeartbeat)
+	defer ping.Stop()
+
+	for {
+		select {
+		case m, ok := <-sub.C:
+			if !ok {
+				if sub.Dropped() {
+					log.Warn("Yavaş akış istemcisi düşürüldü")
+					return true
+				}
+				return false
+			}
+			if wait := opts.interval - time.Since(lastSent); wait > 0 {
+				// Aralık dolana kadar sadece en yeni snapshot saklanır
+				if pending == nil {
+					throttle = time.After(wait)
+				}
+				pending = m
+				continue
+			}
+			if !send(m) {
+				return false
+			}
+			lastSent = time.Now()
+
+		case <-throttle:
+			throttle = nil
+			if !send(pending) {
+				return false
+			}
+			pending = nil
+			lastSent = time.Now()
+
+		case <-ping.C:
+			if heartbeat != nil && heartbeat() != nil {
+				return false
+			}
+
+		case <-done:
+			return false
+		case <-s.streamDone:
+			return false
+		}
+	}
+}
+
+// payload snapshot'ı istemcinin alan filtresine göre mesaja dönüştürür
+func (o streamOptions) payload(m *metrics.SystemMetrics) interface{} {
+	if len(o.fields) == 0 {
+		return metricsResponse{SystemMetrics: m, AgeSeconds: m.Age().Seconds()}
+	}
+
+	values := make(map[string]float64)
+	for path, v := range m.Flatten() {
+		for _, f := range o.fields {
+			if path == f || strings.HasPrefix(path, f+".") {
+				values[path] = v
+				break
+			}
+		}
+	}
+	return streamValues{Timestamp: m.Timestamp, Values: values}
+}
+
+// parseStreamOptions fields ve interval parametrelerini çözümler
+func parseStreamOptions(query url.Values) (streamOptions, error) {
+	var opts streamOptions
+
+	for _, raw := range append(query["fields"], query["field"]...) {
+		for _, f := range strings.Split(raw, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				opts.fields = append(opts.fields, f)
+			}
+		}
+	}
+
+	if raw := query.Get("interval"); raw != "" {
+		interval, err := parseStep(raw)
+		if err != nil {
+			return opts, fmt.Errorf("interval: %w", err)
+		}
+		if interval < 0 {
+			return opts, fmt.Errorf("interval negatif olamaz")
+		}
+		opts.interval = interval
+	}
+
+	return opts, nil
+}
+
+// checkSameOrigin tarayıcılardan gelen WebSocket isteklerini sadece aynı origin'den kabul eder.
+// Origin header'ı olmayan (tarayıcı dışı) istemciler kabul edilir.
+func checkSameOrigin(config *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := sameHost(origin, req)
+	if err != nil {
+		return err
+	}
+	config.Origin = u
+	return nil
+}
+
+// sameHost origin (veya referer) adresinin isteğin yapıldığı host'a ait olduğunu kontrol eder
+func sameHost(origin string, req *http.Request) (*url.URL, error) {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host != req.Host {
+		return nil, fmt.Errorf("izin verilmeyen origin: %s", origin)
+	}
+	return u, nil
+}
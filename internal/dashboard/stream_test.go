@@ -0,0 +1,191 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// snapshotAt verilen zamana ait boş bir snapshot oluşturur
+func snapshotAt(ts time.Time) *metrics.SystemMetrics {
+	return &metrics.SystemMetrics{Timestamp: ts}
+}
+
+// messageTime akış mesajındaki snapshot zamanını döndürür
+func messageTime(t *testing.T, data []byte) time.Time {
+	t.Helper()
+	var msg struct {
+		Timestamp time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("Invalid stream message %s: %v", data, err)
+	}
+	return msg.Timestamp
+}
+
+// receive akıştan bir mesaj bekler
+func receive(t *testing.T, messages <-chan []byte) []byte {
+	t.Helper()
+	select {
+	case data := <-messages:
+		return data
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for stream message")
+		return nil
+	}
+}
+
+func TestRunStreamThrottle(t *testing.T) {
+	store := metrics.NewStore()
+	srv := NewServer(store, "localhost", 8080)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.Publish(snapshotAt(base))
+
+	messages := make(chan []byte, 10)
+	write := func(data []byte) error {
+		messages <- data
+		return nil
+	}
+	done := make(chan struct{})
+	defer close(done)
+
+	interval := 200 * time.Millisecond
+	go srv.runStream(done, streamOptions{interval: interval}, write, nil)
+
+	// Bağlanan istemci son snapshot'ı hemen alır; bu abonelik açıldıktan sonra olur
+	if got := messageTime(t, receive(t, messages)); !got.Equal(base) {
+		t.Fatalf("Expected latest snapshot first, got %v", got)
+	}
+	start := time.Now()
+
+	// Aralık dolmadan gelen snapshot'lardan sadece en yenisi gönderilmeli
+	store.Publish(snapshotAt(base.Add(time.Second)))
+	store.Publish(snapshotAt(base.Add(2 * time.Second)))
+
+	if got := messageTime(t, receive(t, messages)); !got.Equal(base.Add(2 * time.Second)) {
+		t.Errorf("Expected only the newest pending snapshot, got %v", got)
+	}
+	if elapsed := time.Since(start); elapsed < interval-20*time.Millisecond {
+		t.Errorf("Expected pending snapshot after %v, got it after %v", interval, elapsed)
+	}
+
+	select {
+	case data := <-messages:
+		t.Errorf("Unexpected extra message for %v", messageTime(t, data))
+	case <-time.After(interval):
+	}
+}
+
+func TestRunStreamDropsSlowClient(t *testing.T) {
+	store := metrics.NewStore()
+	srv := NewServer(store, "localhost", 8080)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.Publish(snapshotAt(base))
+
+	// İlk yazma istemci okumuyormuş gibi bloklanır
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	write := func(data []byte) error {
+		select {
+		case blocked <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	}
+
+	dropped := make(chan bool, 1)
+	go func() {
+		dropped <- srv.runStream(make(chan struct{}), streamOptions{}, write, nil)
+	}()
+	<-blocked
+
+	// Yavaş istemci toplama döngüsünü bloklamamalı
+	published := make(chan struct{})
+	go func() {
+		for i := 1; i <= streamBuffer+1; i++ {
+			store.Publish(snapshotAt(base.Add(time.Duration(i) * time.Second)))
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish blocked on a slow stream client")
+	}
+
+	close(release)
+	select {
+	case ok := <-dropped:
+		if !ok {
+			t.Error("Expected runStream to report the client as dropped")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for slow client to be dropped")
+	}
+}
+
+func TestStreamStalledClient(t *testing.T) {
+	defer func(timeout time.Duration) { streamWriteTimeout = timeout }(streamWriteTimeout)
+	streamWriteTimeout = 200 * time.Millisecond
+
+	store := metrics.NewStore()
+	srv := NewServer(store, "127.0.0.1", 0)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+
+	// İstek gönderip yanıtı hiç okumayan istemci
+	conn, err := net.Dial("tcp", srv.listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.(*net.TCPConn).SetReadBuffer(4096)
+	fmt.Fprintf(conn, "GET /api/stream HTTP/1.1\r\nHost: %s\r\n\r\n", srv.listener.Addr())
+
+	// Soket buffer'ları dolana kadar büyük snapshot'lar yayınlanır
+	big := strings.Repeat("x", 1<<20)
+	for i := 0; i < 32; i++ {
+		store.Publish(&metrics.SystemMetrics{Timestamp: time.Now(), Errors: map[string]string{"big": big}})
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Bloklanan yazma zaman aşımıyla sonlanmalı; Stop bağlantıyı beklememeli
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := srv.Stop(ctx); err != nil {
+		t.Errorf("Stop() waited for the stalled stream: %v", err)
+	}
+}
+
+func TestStreamInvalidInterval(t *testing.T) {
+	srv := NewServer(metrics.NewStore(), "localhost", 8080)
+	srv.setupRoutes()
+
+	for _, interval := range []string{"-5s", "-1", "abc", "5x"} {
+		req := httptest.NewRequest("GET", "/api/stream?interval="+interval, nil)
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("interval=%s: expected 400, got %d", interval, rec.Code)
+		}
+	}
+
+	opts, err := parseStreamOptions(map[string][]string{"fields": {"cpu.usage, network"}, "interval": {"1.5"}})
+	if err != nil {
+		t.Fatalf("parseStreamOptions failed: %v", err)
+	}
+	if len(opts.fields) != 2 || opts.fields[1] != "network" || opts.interval != 1500*time.Millisecond {
+		t.Errorf("Unexpected options: %+v", opts)
+	}
+}
@@ -0,0 +1,166 @@
+// Package exposition snapshot'ları Prometheus text ve OpenMetrics formatlarında sunar.
+package exposition
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// Format çıktı formatı
+type Format int
+
+// Desteklenen formatlar
+const (
+	FormatText        Format = iota // Prometheus text exposition 0.0.4
+	FormatOpenMetrics               // OpenMetrics 1.0.0
+)
+
+// Content-Type değerleri
+const (
+	ContentTypeText        = "text/plain; version=0.0.4; charset=utf-8"
+	ContentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// Negotiate Accept header'ına göre formatı seçer; OpenMetrics istenmediyse text döner
+func Negotiate(accept string) Format {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.Split(part, ";")[0])
+		if mediaType == "application/openmetrics-text" {
+			return FormatOpenMetrics
+		}
+	}
+	return FormatText
+}
+
+// ContentType formatın Content-Type değerini döndürür
+func (f Format) ContentType() string {
+	if f == FormatOpenMetrics {
+		return ContentTypeOpenMetrics
+	}
+	return ContentTypeText
+}
+
+// Metrik türleri
+const (
+	typeGauge   = "gauge"
+	typeCounter = "counter"
+)
+
+// family aynı isimli metriklerin HELP/TYPE bilgisi ve örnekleri
+type family struct {
+	name    string // Counter'lar için _total soneki olmadan
+	help    string
+	typ     string
+	unit    string
+	samples []sample
+}
+
+// sample tek bir zaman serisi değeri
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// label metrik etiketi
+type label struct {
+	name, value string
+}
+
+// Write snapshot'ı verilen formatta yazar
+func Write(w io.Writer, m *metrics.SystemMetrics, f Format) error {
+	bw := bufio.NewWriter(w)
+	for _, fam := range collect(m) {
+		writeFamily(bw, fam, f)
+	}
+	if f == FormatOpenMetrics {
+		bw.WriteString("# EOF\n")
+	}
+	return bw.Flush()
+}
+
+// writeFamily tek bir metrik ailesini yazar
+func writeFamily(w *bufio.Writer, fam *family, f Format) {
+	sampleName := fam.name
+	if fam.typ == typeCounter {
+		sampleName += "_total"
+	}
+
+	// Text formatında TYPE satırı örnek ismiyle, OpenMetrics'te aile ismiyle yazılır
+	familyName := sampleName
+	if f == FormatOpenMetrics {
+		familyName = fam.name
+	}
+
+	w.WriteString("# HELP " + familyName + " " + escapeHelp(fam.help) + "\n")
+	w.WriteString("# TYPE " + familyName + " " + fam.typ + "\n")
+	if f == FormatOpenMetrics && fam.unit != "" {
+		w.WriteString("# UNIT " + familyName + " " + fam.unit + "\n")
+	}
+
+	for _, s := range fam.samples {
+		w.WriteString(sampleName)
+		if len(s.labels) > 0 {
+			w.WriteByte('{')
+			for i, l := range s.labels {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				w.WriteString(l.name + `="` + escapeLabel(l.value) + `"`)
+			}
+			w.WriteByte('}')
+		}
+		w.WriteString(" " + formatValue(s.value) + "\n")
+	}
+}
+
+// formatValue değeri exposition formatında yazar
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// escapeHelp HELP metnindeki özel karakterleri kaçırır
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+// escapeLabel etiket değerindeki özel karakterleri kaçırır
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// sanitizeName metrik veya etiket ismini [a-zA-Z0-9_] karakterlerine indirger
+func sanitizeName(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package exposition
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// testSnapshot etiketli ve kümülatif değerler içeren örnek snapshot
+func testSnapshot() *metrics.SystemMetrics {
+	return &metrics.SystemMetrics{
+		Timestamp: time.Unix(1700000000, 0),
+		CPU: &metrics.CPUMetrics{
+			Usage: 25,
+			Count: 2,
+			Cores: []metrics.CoreMetrics{{Name: "cpu0", Usage: 50}, {Name: "cpu1", Usage: 0}},
+		},
+		Disk: &metrics.DiskMetrics{
+			Filesystems: []metrics.FilesystemMetrics{
+				{Mountpoint: `/mnt/"quoted"`, Device: "/dev/sdb1", Fstype: "ext4", Usage: 80, Total: 1000},
+			},
+		},
+		Network: &metrics.NetMetrics{
+			Interfaces: []metrics.InterfaceMetrics{{Name: "eth0", BytesRecv: 12345}},
+		},
+		Sources: map[string][]metrics.Sample{
+			"gpu": {{Name: "temp.celsius", Value: 61.5, Type: metrics.SampleGauge, Unit: "celsius"}},
+		},
+		Errors: map[string]string{metrics.SubsystemMemory: "okunamadı"},
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testSnapshot(), FormatText); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		"# TYPE syswatch_cpu_usage_ratio gauge\n",
+		"syswatch_cpu_usage_ratio 0.25\n",
+		`syswatch_cpu_core_usage_ratio{cpu="cpu0"} 0.5` + "\n",
+		"# TYPE syswatch_network_receive_bytes_total counter\n",
+		`syswatch_network_receive_bytes_total{interface="eth0"} 12345` + "\n",
+		`syswatch_filesystem_usage_ratio{mountpoint="/mnt/\"quoted\"",device="/dev/sdb1",fstype="ext4"} 0.8` + "\n",
+		`syswatch_source_temp_celsius{source="gpu"} 61.5` + "\n",
+		`syswatch_collection_error{subsystem="memory"} 1` + "\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected output to contain %q", line)
+		}
+	}
+
+	// Text formatında UNIT ve EOF satırları olmamalı
+	if strings.Contains(out, "# UNIT") || strings.Contains(out, "# EOF") {
+		t.Error("Text format should not contain OpenMetrics-only lines")
+	}
+	// Devre dışı alt sistemler çıktıda yer almamalı
+	if strings.Contains(out, "syswatch_memory_") {
+		t.Error("Expected no memory metrics for missing subsystem")
+	}
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testSnapshot(), FormatOpenMetrics); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		"# TYPE syswatch_network_receive_bytes counter\n",
+		"# UNIT syswatch_network_receive_bytes bytes\n",
+		`syswatch_network_receive_bytes_total{interface="eth0"} 12345` + "\n",
+		"# UNIT syswatch_source_temp_celsius celsius\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected output to contain %q", line)
+		}
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Error("OpenMetrics output must end with # EOF")
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected Format
+	}{
+		{"", FormatText},
+		{"text/plain;version=0.0.4", FormatText},
+		{"application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5", FormatOpenMetrics},
+		{"*/*", FormatText},
+	}
+
+	for _, tt := range tests {
+		if got := Negotiate(tt.accept); got != tt.expected {
+			t.Errorf("Negotiate(%q) = %v, expected %v", tt.accept, got, tt.expected)
+		}
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := map[string]string{
+		"temp.celsius": "temp_celsius",
+		"9lives":       "_9lives",
+		"queue-depth":  "queue_depth",
+	}
+	for in, expected := range tests {
+		if got := sanitizeName(in); got != expected {
+			t.Errorf("sanitizeName(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestSourceTypeConflict(t *testing.T) {
+	snap := &metrics.SystemMetrics{
+		Timestamp: time.Unix(1700000000, 0),
+		Sources: map[string][]metrics.Sample{
+			"a": {{Name: "queue", Value: 3, Type: metrics.SampleCounter}},
+			"b": {{Name: "queue", Value: 7, Type: metrics.SampleGauge}},
+		},
+	}
+
+	for _, format := range []Format{FormatText, FormatOpenMetrics} {
+		var buf bytes.Buffer
+		if err := Write(&buf, snap, format); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		out := buf.String()
+
+		// Metin formatında counter ailesi _total sonekiyle yazılır
+		if n := strings.Count(out, "# TYPE syswatch_source_queue"); n != 1 {
+			t.Errorf("format %v: expected a single TYPE line, got %d:\n%s", format, n, out)
+		}
+		if strings.Contains(out, "# TYPE syswatch_source_queue gauge") {
+			t.Errorf("format %v: expected the first type to win:\n%s", format, out)
+		}
+		if strings.Contains(out, `source="b"`) {
+			t.Errorf("format %v: conflicting gauge sample should be skipped:\n%s", format, out)
+		}
+	}
+}
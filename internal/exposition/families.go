@@ -0,0 +1,223 @@
+package exposition
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// namespace tüm metrik isimlerinin öneki
+const namespace = "syswatch_"
+
+// builder metrik ailelerini eklenme sırasını koruyarak toplar
+type builder struct {
+	families []*family
+	index    map[string]*family
+}
+
+// add örneği ilgili aileye ekler; aile yoksa oluşturur. Aynı isimle farklı
+// türde gelen örnekler (ör. iki kaynaktan biri counter, diğeri gauge) atlanır:
+// ilk eklenen tür geçerli kalır ve scrape geçersiz hale gelmez.
+func (b *builder) add(typ, name, unit, help string, value float64, labels ...label) {
+	fam, ok := b.index[name]
+	if !ok {
+		fam = &family{name: name, help: help, typ: typ, unit: unit}
+		b.index[name] = fam
+		b.families = append(b.families, fam)
+	} else if fam.typ != typ {
+		return
+	}
+	fam.samples = append(fam.samples, sample{labels: labels, value: value})
+}
+
+// gauge anlık değer ekler; isim birimi sonek olarak içermelidir (ör. _bytes)
+func (b *builder) gauge(name, unit, help string, value float64, labels ...label) {
+	b.add(typeGauge, namespace+name, unit, help, value, labels...)
+}
+
+// counter kümülatif değer ekler; isim _total soneki olmadan verilir
+func (b *builder) counter(name, unit, help string, value float64, labels ...label) {
+	b.add(typeCounter, namespace+name, unit, help, value, labels...)
+}
+
+// collect snapshot'ı metrik ailelerine dönüştürür.
+// Yüzdeler Prometheus kuralına uygun olarak 0-1 aralığındaki oranlara çevrilir.
+func collect(m *metrics.SystemMetrics) []*family {
+	b := &builder{index: make(map[string]*family)}
+
+	b.gauge("snapshot_timestamp_seconds", "seconds", "Son snapshot'ın toplandığı zaman (unix).",
+		float64(m.Timestamp.UnixNano())/1e9)
+	subsystems := make([]string, 0, len(m.Errors))
+	for subsystem := range m.Errors {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	for _, subsystem := range subsystems {
+		b.gauge("collection_error", "", "Son toplamada hata veren alt sistem (1 = hata).",
+			1, label{"subsystem", subsystem})
+	}
+
+	if h := m.Host; h != nil {
+		b.gauge("load1", "", "1 dakikalık load average.", h.Load1)
+		b.gauge("load5", "", "5 dakikalık load average.", h.Load5)
+		b.gauge("load15", "", "15 dakikalık load average.", h.Load15)
+		b.gauge("procs_running", "", "Çalışır durumdaki process sayısı.", float64(h.ProcsRunning))
+		b.gauge("procs_blocked", "", "I/O beklerken bloklanmış process sayısı.", float64(h.ProcsBlocked))
+		b.gauge("boot_time_seconds", "seconds", "Sistemin açıldığı zaman (unix).", float64(h.BootTime.Unix()))
+		b.gauge("uptime_seconds", "seconds", "Sistemin açık kalma süresi.", float64(h.Uptime))
+	}
+
+	if c := m.CPU; c != nil {
+		b.gauge("cpu_cores", "", "Mantıksal CPU çekirdek sayısı.", float64(c.Count))
+		b.gauge("cpu_usage_ratio", "ratio", "Tüm çekirdeklerin ortalama kullanım oranı.", c.Usage/100)
+		for _, core := range c.Cores {
+			b.gauge("cpu_core_usage_ratio", "ratio", "Çekirdek bazında kullanım oranı.",
+				core.Usage/100, label{"cpu", core.Name})
+		}
+		modes := []struct {
+			name  string
+			value float64
+		}{
+			{"user", c.User}, {"nice", c.Nice}, {"system", c.System}, {"idle", c.Idle},
+			{"iowait", c.Iowait}, {"irq", c.Irq}, {"softirq", c.Softirq}, {"steal", c.Steal},
+			{"guest", c.Guest},
+		}
+		for _, mode := range modes {
+			b.gauge("cpu_mode_ratio", "ratio", "CPU zamanının moda göre dağılımı.",
+				mode.value/100, label{"mode", mode.name})
+		}
+	}
+
+	if mem := m.Memory; mem != nil {
+		b.gauge("memory_usage_ratio", "ratio", "Bellek kullanım oranı.", mem.Usage/100)
+		b.gauge("memory_total_bytes", "bytes", "Toplam bellek.", float64(mem.Total))
+		b.gauge("memory_available_bytes", "bytes", "Kullanılabilir bellek.", float64(mem.Available))
+		b.gauge("memory_used_bytes", "bytes", "Kullanılan bellek.", float64(mem.Used))
+		b.gauge("memory_free_bytes", "bytes", "Tamamen boş bellek.", float64(mem.Free))
+		b.gauge("memory_buffers_bytes", "bytes", "Blok cihaz buffer'ları.", float64(mem.Buffers))
+		b.gauge("memory_cached_bytes", "bytes", "Sayfa önbelleği.", float64(mem.Cached))
+		b.gauge("memory_shared_bytes", "bytes", "Paylaşılan bellek.", float64(mem.Shared))
+		b.gauge("memory_slab_bytes", "bytes", "Kernel slab.", float64(mem.Slab))
+		b.gauge("memory_dirty_bytes", "bytes", "Diske yazılmayı bekleyen bellek.", float64(mem.Dirty))
+		b.gauge("memory_writeback_bytes", "bytes", "Şu an diske yazılan bellek.", float64(mem.WriteBack))
+
+		b.gauge("swap_usage_ratio", "ratio", "Swap kullanım oranı.", mem.Swap.Usage/100)
+		b.gauge("swap_total_bytes", "bytes", "Toplam swap.", float64(mem.Swap.Total))
+		b.gauge("swap_used_bytes", "bytes", "Kullanılan swap.", float64(mem.Swap.Used))
+		b.gauge("swap_free_bytes", "bytes", "Boş swap.", float64(mem.Swap.Free))
+		b.gauge("swap_in_bytes_per_second", "", "Swap'tan belleğe okuma hızı.", mem.Swap.InBytesPerSec)
+		b.gauge("swap_out_bytes_per_second", "", "Bellekten swap'a yazma hızı.", mem.Swap.OutBytesPerSec)
+	}
+
+	if d := m.Disk; d != nil {
+		for _, fs := range d.Filesystems {
+			labels := []label{{"mountpoint", fs.Mountpoint}, {"device", fs.Device}, {"fstype", fs.Fstype}}
+			b.gauge("filesystem_size_bytes", "bytes", "Dosya sisteminin toplam alanı.", float64(fs.Total), labels...)
+			b.gauge("filesystem_used_bytes", "bytes", "Dosya sisteminde kullanılan alan.", float64(fs.Used), labels...)
+			b.gauge("filesystem_free_bytes", "bytes", "Dosya sistemindeki boş alan.", float64(fs.Free), labels...)
+			b.gauge("filesystem_usage_ratio", "ratio", "Dosya sistemi kullanım oranı.", fs.Usage/100, labels...)
+			b.gauge("filesystem_inodes", "", "Toplam inode sayısı.", float64(fs.InodesTotal), labels...)
+			b.gauge("filesystem_inodes_free", "", "Boş inode sayısı.", float64(fs.InodesFree), labels...)
+			b.gauge("filesystem_inodes_usage_ratio", "ratio", "Inode kullanım oranı.", fs.InodesUsage/100, labels...)
+			if fs.GrowthBytesPerHour != nil {
+				b.gauge("filesystem_growth_bytes_per_hour", "", "Kullanılan alanın doğrusal regresyonla tahmin edilen saatlik artışı.", *fs.GrowthBytesPerHour, labels...)
+			}
+			if fs.TimeToFull != nil {
+				b.gauge("filesystem_time_to_full_seconds", "seconds", "Mevcut büyüme hızıyla tahmini dolma süresi.", *fs.TimeToFull, labels...)
+			}
+		}
+	}
+
+	if io := m.DiskIO; io != nil {
+		for _, dev := range io.Devices {
+			l := label{"device", dev.Name}
+			b.counter("disk_read_bytes", "bytes", "Cihazdan okunan toplam veri.", float64(dev.ReadBytes), l)
+			b.counter("disk_written_bytes", "bytes", "Cihaza yazılan toplam veri.", float64(dev.WriteBytes), l)
+			b.counter("disk_reads_completed", "", "Tamamlanan okuma işlemi sayısı.", float64(dev.ReadCount), l)
+			b.counter("disk_writes_completed", "", "Tamamlanan yazma işlemi sayısı.", float64(dev.WriteCount), l)
+			b.gauge("disk_io_in_progress", "", "Devam eden I/O işlemi sayısı.", float64(dev.InProgress), l)
+			b.gauge("disk_io_await_seconds", "seconds", "Son aralıktaki ortalama I/O bekleme süresi.", dev.AwaitMs/1000, l)
+			b.gauge("disk_io_queue_depth", "", "Son aralıktaki ortalama kuyruk uzunluğu.", dev.QueueDepth, l)
+			b.gauge("disk_io_utilization_ratio", "ratio", "Cihazın meşgul olduğu sürenin oranı.", dev.Utilization/100, l)
+		}
+	}
+
+	if n := m.Network; n != nil {
+		for _, iface := range n.Interfaces {
+			l := label{"interface", iface.Name}
+			b.counter("network_receive_bytes", "bytes", "Interface'den alınan toplam veri.", float64(iface.BytesRecv), l)
+			b.counter("network_transmit_bytes", "bytes", "Interface'den gönderilen toplam veri.", float64(iface.BytesSent), l)
+			b.counter("network_receive_packets", "", "Interface'den alınan toplam paket.", float64(iface.PacketsRecv), l)
+			b.counter("network_transmit_packets", "", "Interface'den gönderilen toplam paket.", float64(iface.PacketsSent), l)
+			b.gauge("network_receive_errors_per_second", "", "Son aralıktaki alma hatası hızı.", iface.ErrinPerSec, l)
+			b.gauge("network_transmit_errors_per_second", "", "Son aralıktaki gönderme hatası hızı.", iface.ErroutPerSec, l)
+			b.gauge("network_receive_drops_per_second", "", "Son aralıktaki düşürülen gelen paket hızı.", iface.DropinPerSec, l)
+			b.gauge("network_transmit_drops_per_second", "", "Son aralıktaki düşürülen giden paket hızı.", iface.DropoutPerSec, l)
+		}
+	}
+
+	for _, w := range m.Watchlist {
+		l := label{"name", w.Name}
+		up := 0.0
+		if w.Up {
+			up = 1
+		}
+		b.gauge("process_up", "", "İzlenen process çalışıyor mu (1 = evet).", up, l)
+		b.counter("process_restarts", "", "Daemon başladığından beri yeniden başlama sayısı.", float64(w.Restarts), l)
+		b.gauge("process_matches", "", "İzleme tanımına eşleşen process sayısı.", float64(w.Matches), l)
+		if !w.Up {
+			continue
+		}
+		b.gauge("process_cpu_ratio", "ratio", "İzlenen process'in CPU kullanımı (1 = bir çekirdek).", w.CPUPercent/100, l)
+		b.gauge("process_resident_memory_bytes", "bytes", "İzlenen process'in RSS belleği.", float64(w.RSS), l)
+		b.gauge("process_threads", "", "İzlenen process'in thread sayısı.", float64(w.Threads), l)
+		b.gauge("process_start_time_seconds", "seconds", "İzlenen process'in başlama zamanı (unix).", float64(w.StartTime.Unix()), l)
+	}
+
+	addSources(b, m.Sources)
+
+	return b.families
+}
+
+// addSources eklenti kaynaklarının örneklerini source_<isim> ailelerine ekler
+func addSources(b *builder, sources map[string][]metrics.Sample) {
+	ids := make([]string, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		for _, s := range sources[id] {
+			labels := []label{{"source", id}}
+			keys := make([]string, 0, len(s.Labels))
+			for k := range s.Labels {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if name := sanitizeName(k); name != "source" {
+					labels = append(labels, label{name, s.Labels[k]})
+				}
+			}
+
+			name := "source_" + sanitizeName(s.Name)
+			if s.Type == metrics.SampleCounter {
+				name = strings.TrimSuffix(name, "_total")
+			}
+			unit := sanitizeName(s.Unit)
+			// OpenMetrics birimin isim soneki olmasını şart koşar
+			if unit != "" && !strings.HasSuffix(name, "_"+unit) {
+				unit = ""
+			}
+
+			help := "Eklenti kaynağı metriği: " + s.Name
+			if s.Type == metrics.SampleCounter {
+				b.counter(name, unit, help, s.Value, labels...)
+			} else {
+				b.gauge(name, unit, help, s.Value, labels...)
+			}
+		}
+	}
+}
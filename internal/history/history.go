@@ -0,0 +1,358 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// MaxPoints bir sorguda seri başına döndürülebilecek en fazla adım sayısı
+const MaxPoints = 11000
+
+// Record sorgulanabilir tek bir zaman noktası.
+// *metrics.SystemMetrics ve kalıcı depolamadaki kayıtlar bu arayüzü sağlar.
+type Record interface {
+	Time() time.Time
+	Value(field string) (float64, bool)
+}
+
+// SummaryRecord önceden özetlenmiş (rollup) bir zaman aralığını temsil eden kayıt.
+// Aggregate bu kayıtları tek bir değer yerine özet olarak birleştirir.
+type SummaryRecord interface {
+	Record
+	Summary(field string) (Summary, bool)
+}
+
+// Summary bir zaman aralığındaki değerlerin özeti
+type Summary struct {
+	Min   float64
+	Max   float64
+	Sum   float64
+	Last  float64
+	P95   float64
+	Count int
+}
+
+// Avg özetin ortalamasını döndürür
+func (s Summary) Avg() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// Summarize zaman sırasındaki değerlerin özetini hesaplar
+func Summarize(values []float64) Summary {
+	var acc accumulator
+	for _, v := range values {
+		acc.add(v)
+	}
+	return acc.summary()
+}
+
+// Querier geçmiş sorgularını yanıtlayan kaynak
+type Querier interface {
+	Query(q Query) (*Result, error)
+}
+
+// Buffer son snapshot'ları sınırlı bir ring buffer'da saklar.
+// Eski kayıtlar hem kapasite hem de saklama süresi dolduğunda atılır.
+type Buffer struct {
+	mu        sync.RWMutex
+	samples   []*metrics.SystemMetrics
+	start     int // En eski kaydın indeksi
+	count     int
+	retention time.Duration
+}
+
+// New verilen kapasite ve saklama süresi ile yeni buffer oluşturur.
+// retention 0 ise sadece kapasite sınırı uygulanır.
+func New(capacity int, retention time.Duration) *Buffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Buffer{
+		samples:   make([]*metrics.SystemMetrics, capacity),
+		retention: retention,
+	}
+}
+
+// Add yeni snapshot'ı buffer'a ekler; kapasite doluysa en eski kayıt ezilir
+func (b *Buffer) Add(m *metrics.SystemMetrics) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	capacity := len(b.samples)
+	if b.count < capacity {
+		b.samples[(b.start+b.count)%capacity] = m
+		b.count++
+	} else {
+		b.samples[b.start] = m
+		b.start = (b.start + 1) % capacity
+	}
+
+	b.expire(m.Timestamp)
+}
+
+// expire saklama süresini aşan kayıtları atar
+func (b *Buffer) expire(now time.Time) {
+	if b.retention <= 0 {
+		return
+	}
+
+	cutoff := now.Add(-b.retention)
+	for b.count > 0 {
+		oldest := b.samples[b.start]
+		if !oldest.Timestamp.Before(cutoff) {
+			return
+		}
+		b.samples[b.start] = nil
+		b.start = (b.start + 1) % len(b.samples)
+		b.count--
+	}
+}
+
+// Len buffer'daki kayıt sayısını döndürür
+func (b *Buffer) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.count
+}
+
+// Oldest buffer'daki en eski kaydın zamanını döndürür; buffer boşsa false döner
+func (b *Buffer) Oldest() (time.Time, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.count == 0 {
+		return time.Time{}, false
+	}
+	return b.samples[b.start].Timestamp, true
+}
+
+// Range [from, to] aralığındaki snapshot'ları eskiden yeniye döndürür
+func (b *Buffer) Range(from, to time.Time) []*metrics.SystemMetrics {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	result := make([]*metrics.SystemMetrics, 0, b.count)
+	for i := 0; i < b.count; i++ {
+		m := b.samples[(b.start+i)%len(b.samples)]
+		if m.Timestamp.Before(from) || m.Timestamp.After(to) {
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// Query geçmiş sorgusu parametreleri
+type Query struct {
+	From   time.Time
+	To     time.Time
+	Step   time.Duration
+	Fields []string
+}
+
+// Point bir adım aralığındaki değerlerin özeti; veri yoksa değerler nil kalır
+type Point struct {
+	Time  time.Time `json:"t"`
+	Min   *float64  `json:"min"`
+	Max   *float64  `json:"max"`
+	Avg   *float64  `json:"avg"`
+	Last  *float64  `json:"last"`
+	P95   *float64  `json:"p95"`
+	Count int       `json:"count"`
+}
+
+// Series tek bir alanın adım adım hizalanmış değerleri
+type Series struct {
+	Field  string  `json:"field"`
+	Points []Point `json:"points"`
+}
+
+// Result geçmiş sorgusunun sonucu
+type Result struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Step   float64   `json:"step"` // saniye
+	Series []Series  `json:"series"`
+}
+
+// Validate sorgu parametrelerini kontrol eder
+func (q Query) Validate() error {
+	if len(q.Fields) == 0 {
+		return fmt.Errorf("en az bir alan belirtilmeli")
+	}
+	if !q.To.After(q.From) {
+		return fmt.Errorf("to, from'dan sonra olmalı")
+	}
+	if q.Step <= 0 {
+		return fmt.Errorf("step pozitif olmalı")
+	}
+	if points := int64(q.To.Sub(q.From) / q.Step); points > MaxPoints {
+		return fmt.Errorf("çok fazla adım: %d (en fazla %d, step'i büyütün)", points, MaxPoints)
+	}
+	return nil
+}
+
+// Query buffer'daki verileri adım aralıklarına bölerek her alan için min/max/avg hesaplar
+func (b *Buffer) Query(q Query) (*Result, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	samples := b.Range(q.From, q.To)
+	records := make([]Record, len(samples))
+	for i, m := range samples {
+		records[i] = m
+	}
+
+	return Aggregate(records, q), nil
+}
+
+// Aggregate verilen kayıtları sorgudaki adımlara hizalayarak özetler.
+// Adımlar step'in katlarına yuvarlanır; böylece farklı sorgular aynı sınırları paylaşır.
+func Aggregate(records []Record, q Query) *Result {
+	start := q.From.Truncate(q.Step)
+	buckets := int(q.To.Sub(start)/q.Step) + 1
+
+	result := &Result{
+		From:   start,
+		To:     q.To,
+		Step:   q.Step.Seconds(),
+		Series: make([]Series, 0, len(q.Fields)),
+	}
+
+	for _, field := range q.Fields {
+		acc := make([]accumulator, buckets)
+		for _, r := range records {
+			idx := int(r.Time().Sub(start) / q.Step)
+			if idx < 0 || idx >= buckets {
+				continue
+			}
+			if sr, ok := r.(SummaryRecord); ok {
+				if sum, ok := sr.Summary(field); ok {
+					acc[idx].addSummary(sum)
+				}
+				continue
+			}
+			if v, ok := r.Value(field); ok {
+				acc[idx].add(v)
+			}
+		}
+
+		series := Series{Field: field, Points: make([]Point, buckets)}
+		for i := range acc {
+			series.Points[i] = acc[i].point(start.Add(time.Duration(i) * q.Step))
+		}
+		result.Series = append(result.Series, series)
+	}
+
+	return result
+}
+
+// accumulator bir adımdaki ham değerleri ve rollup özetlerini biriktirir.
+// Ham değerlerin p95'i tam hesaplanır; rollup özetleri birleştirilirken
+// en yüksek p95 alınır (üst sınır yaklaşımı).
+type accumulator struct {
+	total  Summary   // P95 hariç birleşik özet
+	values []float64 // p95 için ham değerler
+	p95    float64   // Rollup özetlerinden gelen en yüksek p95
+	rolled bool
+}
+
+// add yeni ham değeri biriktirir
+func (a *accumulator) add(v float64) {
+	a.total.merge(Summary{Min: v, Max: v, Sum: v, Last: v, Count: 1})
+	a.values = append(a.values, v)
+}
+
+// addSummary önceden özetlenmiş aralığı biriktirir
+func (a *accumulator) addSummary(s Summary) {
+	if s.Count == 0 {
+		return
+	}
+	a.total.merge(s)
+	if !a.rolled || s.P95 > a.p95 {
+		a.p95 = s.P95
+	}
+	a.rolled = true
+}
+
+// summary biriken değerlerin özetini döndürür
+func (a *accumulator) summary() Summary {
+	s := a.total
+	if len(a.values) > 0 {
+		s.P95 = percentile(a.values, 0.95)
+	}
+	if a.rolled && (len(a.values) == 0 || a.p95 > s.P95) {
+		s.P95 = a.p95
+	}
+	return s
+}
+
+// point biriken değerlerden Point oluşturur
+func (a *accumulator) point(t time.Time) Point {
+	s := a.summary()
+	p := Point{Time: t, Count: s.Count}
+	if s.Count > 0 {
+		min, max, avg, last, p95 := s.Min, s.Max, s.Avg(), s.Last, s.P95
+		p.Min, p.Max, p.Avg, p.Last, p.P95 = &min, &max, &avg, &last, &p95
+	}
+	return p
+}
+
+// merge başka bir özeti (zaman sırasında sonra gelen) bu özete ekler
+func (s *Summary) merge(o Summary) {
+	if o.Count == 0 {
+		return
+	}
+	if s.Count == 0 {
+		*s = o
+		return
+	}
+	s.Min = math.Min(s.Min, o.Min)
+	s.Max = math.Max(s.Max, o.Max)
+	s.Sum += o.Sum
+	s.Last = o.Last
+	s.Count += o.Count
+}
+
+// percentile değerlerin verilen yüzdeliğini en yakın sıra yöntemiyle hesaplar
+func percentile(values []float64, q float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// Fallback yakın geçmişi bellekten, daha eskisini arşivden (kalıcı depolama) yanıtlar
+type Fallback struct {
+	Recent  *Buffer
+	Archive Querier
+}
+
+// Query sorgu aralığı bellekteki buffer tarafından karşılanıyorsa onu,
+// aksi halde arşivi kullanır
+func (f *Fallback) Query(q Query) (*Result, error) {
+	if f.Recent != nil {
+		if oldest, ok := f.Recent.Oldest(); ok && !q.From.Before(oldest) {
+			return f.Recent.Query(q)
+		}
+	}
+	if f.Archive != nil {
+		return f.Archive.Query(q)
+	}
+	if f.Recent != nil {
+		return f.Recent.Query(q)
+	}
+	return nil, fmt.Errorf("geçmiş kaynağı yok")
+}
@@ -0,0 +1,142 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// snapshot test için sadece CPU kullanımı içeren snapshot oluşturur
+func snapshot(t time.Time, cpu float64) *metrics.SystemMetrics {
+	return &metrics.SystemMetrics{
+		Timestamp: t,
+		CPU:       &metrics.CPUMetrics{Usage: cpu},
+	}
+}
+
+func TestBufferCapacity(t *testing.T) {
+	buf := New(3, 0)
+	base := time.Now()
+
+	for i := 0; i < 5; i++ {
+		buf.Add(snapshot(base.Add(time.Duration(i)*time.Second), float64(i)))
+	}
+
+	if buf.Len() != 3 {
+		t.Fatalf("Expected 3 samples, got %d", buf.Len())
+	}
+
+	// En eski iki kayıt ezilmiş olmalı, sıra korunmalı
+	samples := buf.Range(base, base.Add(time.Minute))
+	for i, m := range samples {
+		if m.CPU.Usage != float64(i+2) {
+			t.Errorf("Sample %d: expected cpu %d, got %.0f", i, i+2, m.CPU.Usage)
+		}
+	}
+}
+
+func TestBufferRetention(t *testing.T) {
+	buf := New(100, 10*time.Second)
+	base := time.Now()
+
+	buf.Add(snapshot(base, 1))
+	buf.Add(snapshot(base.Add(5*time.Second), 2))
+	buf.Add(snapshot(base.Add(12*time.Second), 3))
+
+	// İlk kayıt saklama süresinin dışında kalmalı
+	if buf.Len() != 2 {
+		t.Errorf("Expected 2 samples after retention, got %d", buf.Len())
+	}
+}
+
+func TestQuery(t *testing.T) {
+	buf := New(100, 0)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// İlk dakika: 10, 20, 30; ikinci dakika boş; üçüncü dakika: 50
+	buf.Add(snapshot(base.Add(0*time.Second), 10))
+	buf.Add(snapshot(base.Add(20*time.Second), 20))
+	buf.Add(snapshot(base.Add(40*time.Second), 30))
+	buf.Add(snapshot(base.Add(130*time.Second), 50))
+
+	result, err := buf.Query(Query{
+		From:   base,
+		To:     base.Add(179 * time.Second),
+		Step:   time.Minute,
+		Fields: []string{"cpu.usage", "memory.usage"},
+	})
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+
+	if len(result.Series) != 2 {
+		t.Fatalf("Expected 2 series, got %d", len(result.Series))
+	}
+
+	points := result.Series[0].Points
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 aligned points, got %d", len(points))
+	}
+
+	first := points[0]
+	if first.Count != 3 || *first.Min != 10 || *first.Max != 30 || *first.Avg != 20 {
+		t.Errorf("Unexpected first bucket: count=%d min=%v max=%v avg=%v", first.Count, *first.Min, *first.Max, *first.Avg)
+	}
+
+	// Boş adımda değerler nil olmalı
+	if points[1].Count != 0 || points[1].Avg != nil {
+		t.Errorf("Expected empty second bucket, got %+v", points[1])
+	}
+
+	if points[2].Count != 1 || *points[2].Avg != 50 {
+		t.Errorf("Unexpected third bucket: %+v", points[2])
+	}
+
+	// Snapshot'ta olmayan alan için tüm adımlar boş olmalı
+	for _, p := range result.Series[1].Points {
+		if p.Count != 0 {
+			t.Errorf("Expected no data for missing field, got %+v", p)
+		}
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name  string
+		query Query
+	}{
+		{"no fields", Query{From: now.Add(-time.Hour), To: now, Step: time.Minute}},
+		{"inverted range", Query{From: now, To: now.Add(-time.Hour), Step: time.Minute, Fields: []string{"cpu.usage"}}},
+		{"zero step", Query{From: now.Add(-time.Hour), To: now, Fields: []string{"cpu.usage"}}},
+		{"too many points", Query{From: now.Add(-24 * time.Hour), To: now, Step: time.Second, Fields: []string{"cpu.usage"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.query.Validate(); err == nil {
+				t.Error("Expected validation error, but got none")
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i + 1)
+	}
+
+	s := Summarize(values)
+	if s.Count != 100 || s.Min != 1 || s.Max != 100 || s.Last != 100 {
+		t.Errorf("Unexpected summary: %+v", s)
+	}
+	if s.Avg() != 50.5 {
+		t.Errorf("Expected avg 50.5, got %.2f", s.Avg())
+	}
+	if s.P95 != 95 {
+		t.Errorf("Expected p95 95, got %.1f", s.P95)
+	}
+}
@@ -1,31 +1,147 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
-var log *logrus.Logger
+var (
+	log *logrus.Logger
 
-// Init logger'ı başlatır
+	// Dosyaya yazılıyorsa açık log dosyası (SIGUSR1 ile yeniden açılır)
+	fileMu sync.Mutex
+	file   *RotatingFile
+)
+
+// Options logger ayarları (config.LoggingConfig'in karşılığı)
+type Options struct {
+	Level    string // debug, info, warn, error (boşsa info)
+	Format   string // text, json (boşsa text)
+	Output   string // stdout, stderr, file (boşsa stdout)
+	Filename string // output=file ise log dosyası
+
+	// Dosya rotasyonu
+	MaxSizeMB   int
+	MaxAgeHours int
+	MaxBackups  int
+	Compress    bool
+}
+
+// Init logger'ı varsayılan ayarlarla (info, text, stdout) başlatır
 func Init() {
 	log = logrus.New()
+	log.SetFormatter(newFormatter("text"))
+	log.SetLevel(logrus.InfoLevel)
+	log.SetOutput(os.Stdout)
+
+	log.Info("Logger başarıyla başlatıldı")
+}
+
+// Configure logger'ı verilen ayarlarla yeniden yapılandırır.
+// Hata durumunda önceki ayarlar korunur.
+func Configure(opts Options) error {
+	level := logrus.InfoLevel
+	if opts.Level != "" {
+		lvl, err := logrus.ParseLevel(opts.Level)
+		if err != nil {
+			return err
+		}
+		level = lvl
+	}
+
+	var (
+		output  io.Writer
+		newFile *RotatingFile
+	)
+	switch opts.Output {
+	case "", "stdout":
+		output = os.Stdout
+	case "stderr":
+		output = os.Stderr
+	case "file":
+		f, err := OpenRotatingFile(opts.Filename, RotateOptions{
+			MaxSize:    int64(opts.MaxSizeMB) * 1024 * 1024,
+			MaxAge:     time.Duration(opts.MaxAgeHours) * time.Hour,
+			MaxBackups: opts.MaxBackups,
+			Compress:   opts.Compress,
+		})
+		if err != nil {
+			return err
+		}
+		output, newFile = f, f
+	default:
+		return fmt.Errorf("geçersiz log output: %s", opts.Output)
+	}
+
+	// Configure ile başlatılan logger varsayılan çıktıya başlangıç mesajı yazmaz
+	if log == nil {
+		log = logrus.New()
+	}
+	l := log
+	l.SetFormatter(newFormatter(opts.Format))
+	l.SetLevel(level)
+	l.SetOutput(output)
+
+	// Önceki dosya yeni çıktıya geçildikten sonra kapatılır
+	fileMu.Lock()
+	old := file
+	file = newFile
+	fileMu.Unlock()
+	if old != nil {
+		old.Close()
+	}
 
-	// Log formatını ayarla
-	log.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp: true,
+	if newFile != nil {
+		watchReopenSignal()
+	}
+	return nil
+}
+
+// newFormatter verilen formata uygun logrus formatter'ı oluşturur.
+// Renkler sadece terminale yazılırken kullanılır (journald ve dosyalara ANSI kodu yazılmaz).
+func newFormatter(format string) logrus.Formatter {
+	if format == "json" {
+		return &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		}
+	}
+	return &logrus.TextFormatter{
+		FullTimestamp:   true,
 		TimestampFormat: "2006-01-02 15:04:05",
-		ForceColors: true,
-	})
+	}
+}
 
-	// Log level'ı ayarla (development için debug)
-	log.SetLevel(logrus.InfoLevel)
+// Reopen log dosyasını kapatıp aynı yolla yeniden açar (harici logrotate sonrası).
+// Dosyaya yazılmıyorsa bir şey yapmaz.
+func Reopen() error {
+	fileMu.Lock()
+	f := file
+	fileMu.Unlock()
 
-	// Output'u ayarla
-	log.SetOutput(os.Stdout)
+	if f == nil {
+		return nil
+	}
+	return f.Reopen()
+}
 
-	log.Info("Logger başarıyla başlatıldı")
+// Close log dosyasını (açıksa) kapatır ve bekleyen sıkıştırmaların bitmesini bekler.
+// Sonraki loglar stdout'a yazılır.
+func Close() error {
+	fileMu.Lock()
+	f := file
+	file = nil
+	fileMu.Unlock()
+
+	if f == nil {
+		return nil
+	}
+	GetLogger().SetOutput(os.Stdout)
+	return f.Close()
 }
 
 // GetLogger global logger instance'ını döndürür
@@ -34,4 +150,14 @@ func GetLogger() *logrus.Logger {
 		Init()
 	}
 	return log
-}
\ No newline at end of file
+}
+
+// SetLevel log level'ı string değerden ayarlar (debug, info, warn, error)
+func SetLevel(level string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	GetLogger().SetLevel(lvl)
+	return nil
+}
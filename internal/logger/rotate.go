@@ -0,0 +1,254 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat döndürülen dosya isimlerindeki zaman damgası (syswatch-20240310T020000.000.log)
+const backupTimeFormat = "20060102T150405.000"
+
+// RotateOptions log dosyası rotasyon ayarları; sıfır değerler sınırsız anlamına gelir
+type RotateOptions struct {
+	MaxSize    int64         // Bu boyutu (bytes) aşacak yazmadan önce dosya döndürülür
+	MaxAge     time.Duration // Açıldığından beri bu süre geçen dosya döndürülür
+	MaxBackups int           // Saklanacak döndürülmüş dosya sayısı
+	Compress   bool          // Döndürülen dosyalar gzip ile sıkıştırılır
+}
+
+// RotatingFile boyut ve yaşa göre kendini döndüren log dosyası.
+// Sıkıştırma ve eski dosyaların silinmesi yazmaları bekletmemek için arka planda yapılır.
+type RotatingFile struct {
+	path string
+	opts RotateOptions
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	post sync.Mutex     // Arka plan işlemlerini sıraya koyar
+	wg   sync.WaitGroup // Close'un arka plan işlerini beklemesi için
+}
+
+// OpenRotatingFile log dosyasını ekleme modunda açar (yoksa oluşturur)
+func OpenRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, opts: opts}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open dosyayı açar (çağıran kilidi tutmalı veya henüz paylaşılmamış olmalı)
+func (r *RotatingFile) open() error {
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("log dizini oluşturulamadı: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("log dosyası açılamadı: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("log dosyası okunamadı: %w", err)
+	}
+
+	r.file = f
+	r.size = info.Size()
+	r.openedAt = time.Now()
+	return nil
+}
+
+// Write veriyi dosyaya yazar; gerekirse önce dosyayı döndürür
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	sizeExceeded := r.opts.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.opts.MaxSize
+	tooOld := r.opts.MaxAge > 0 && time.Since(r.openedAt) >= r.opts.MaxAge
+	if sizeExceeded || tooOld {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate dosyayı hemen döndürür
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+// rotate mevcut dosyayı zaman damgalı isimle taşır ve yenisini açar (çağıran kilidi tutmalı)
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	backup := r.backupName(time.Now())
+	if err := os.Rename(r.path, backup); err != nil && !os.IsNotExist(err) {
+		// Taşınamayan dosyaya yazmaya devam edilir
+		r.open()
+		return fmt.Errorf("log dosyası döndürülemedi: %w", err)
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.post.Lock()
+		defer r.post.Unlock()
+
+		if r.opts.Compress {
+			if err := compressFile(backup); err != nil {
+				fmt.Fprintf(os.Stderr, "Log dosyası sıkıştırılamadı (%s): %v\n", backup, err)
+			}
+		}
+		r.prune()
+	}()
+	return nil
+}
+
+// Reopen dosyayı kapatıp aynı yolla yeniden açar. Harici bir araç (logrotate)
+// dosyayı taşıdıktan sonra yeni dosyaya yazmak için kullanılır.
+func (r *RotatingFile) Reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+	return r.open()
+}
+
+// Close dosyayı kapatır ve arka plan işlerinin bitmesini bekler
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+		r.file = nil
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	return err
+}
+
+// backupName döndürülen dosyanın ismini oluşturur
+func (r *RotatingFile) backupName(t time.Time) string {
+	dir := filepath.Dir(r.path)
+	base := filepath.Base(r.path)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext)
+	return filepath.Join(dir, fmt.Sprintf("%s-%s%s", prefix, t.Format(backupTimeFormat), ext))
+}
+
+// backups döndürülmüş dosyaları yeniden eskiye sıralı döndürür
+func (r *RotatingFile) backups() ([]string, error) {
+	dir := filepath.Dir(r.path)
+	base := filepath.Base(r.path)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type backup struct {
+		path string
+		t    time.Time
+	}
+	var found []backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, prefix)
+		stamp = strings.TrimSuffix(strings.TrimSuffix(stamp, ".gz"), ext)
+		t, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		found = append(found, backup{path: filepath.Join(dir, name), t: t})
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].t.After(found[j].t) })
+	paths := make([]string, len(found))
+	for i, b := range found {
+		paths[i] = b.path
+	}
+	return paths, nil
+}
+
+// prune MaxBackups'tan fazla olan en eski dosyaları siler
+func (r *RotatingFile) prune() {
+	if r.opts.MaxBackups <= 0 {
+		return
+	}
+	backups, err := r.backups()
+	if err != nil {
+		return
+	}
+	for _, path := range backups[min(len(backups), r.opts.MaxBackups):] {
+		os.Remove(path)
+	}
+}
+
+// compressFile dosyayı path.gz olarak sıkıştırır ve orijinalini siler
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
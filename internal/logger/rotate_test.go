@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	r, err := OpenRotatingFile(path, RotateOptions{MaxSize: 100, MaxBackups: 2, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := []byte(strings.Repeat("x", 59) + "\n")
+	for i := 0; i < 8; i++ {
+		if _, err := r.Write(line); err != nil {
+			t.Fatal(err)
+		}
+		// Yedek isimleri milisaniye çözünürlüklü; çakışmayı önle
+		time.Sleep(2 * time.Millisecond)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != len(line) {
+		t.Errorf("Expected active file to hold one line, got %d bytes", len(data))
+	}
+
+	backups, err := r.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups after pruning, got %v", backups)
+	}
+	for _, b := range backups {
+		if !strings.HasSuffix(b, ".log.gz") {
+			t.Errorf("Expected compressed backup, got %s", b)
+		}
+	}
+}
+
+func TestRotatingFileAgeAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	r, err := OpenRotatingFile(path, RotateOptions{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	r.Write([]byte("first\n"))
+
+	// Yaşı dolan dosya bir sonraki yazmada döndürülmeli
+	r.openedAt = time.Now().Add(-2 * time.Hour)
+	r.Write([]byte("second\n"))
+	if backups, _ := r.backups(); len(backups) != 1 {
+		t.Fatalf("Expected 1 backup after age rotation, got %v", backups)
+	}
+
+	// Harici logrotate dosyayı taşıdıktan sonra Reopen yeni dosya oluşturmalı
+	moved := filepath.Join(dir, "moved.log")
+	if err := os.Rename(path, moved); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	r.Write([]byte("third\n"))
+
+	if data, _ := os.ReadFile(path); string(data) != "third\n" {
+		t.Errorf("Expected new file after reopen, got %q", data)
+	}
+	if data, _ := os.ReadFile(moved); string(data) != "second\n" {
+		t.Errorf("Expected moved file to keep old content, got %q", data)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !windows
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+var reopenOnce sync.Once
+
+// watchReopenSignal SIGUSR1 alındığında log dosyasını yeniden açar (logrotate postrotate için)
+func watchReopenSignal() {
+	reopenOnce.Do(func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGUSR1)
+		go func() {
+			for range ch {
+				if err := Reopen(); err != nil {
+					GetLogger().Errorf("Log dosyası yeniden açılamadı: %v", err)
+					continue
+				}
+				GetLogger().Info("Log dosyası yeniden açıldı")
+			}
+		}()
+	})
+}
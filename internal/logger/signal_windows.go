@@ -0,0 +1,6 @@
+//go:build windows
+
+package logger
+
+// watchReopenSignal Windows'ta SIGUSR1 olmadığından bir şey yapmaz
+func watchReopenSignal() {}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+)
+
+// subsystem yerleşik bir alt sistemin toplama mantığı. Oran hesaplayan alt
+// sistemler önceki örnekleri kendi içinde tutar.
+type subsystem interface {
+	// start ayarları alır ve gerekiyorsa ilk örneği kaydeder
+	start(cfg config.MetricsConfig)
+	// collectInto ölçümü snapshot'taki alt sistem alanına yazar
+	collectInto(m *SystemMetrics) error
+}
+
+// historySubsystem geçmiş verisine ihtiyaç duyan alt sistemler (ör. disk doluluk tahmini)
+type historySubsystem interface {
+	setHistory(h UsageHistory)
+}
+
+// builtinSubsystem yerleşik alt sistemin kayıt bilgileri
+type builtinSubsystem struct {
+	name    string
+	enabled func(cfg config.MetricsConfig) bool // enable_* ayarı
+	create  func() subsystem
+}
+
+// builtins yerleşik alt sistemler; CollectAll bu sırayla toplar
+var builtins = []builtinSubsystem{
+	{SubsystemHost, func(cfg config.MetricsConfig) bool { return cfg.EnableHost }, func() subsystem { return &hostSubsystem{} }},
+	{SubsystemCPU, func(cfg config.MetricsConfig) bool { return cfg.EnableCPU }, func() subsystem { return &cpuSubsystem{} }},
+	{SubsystemMemory, func(cfg config.MetricsConfig) bool { return cfg.EnableMemory }, func() subsystem { return &memorySubsystem{} }},
+	{SubsystemDisk, func(cfg config.MetricsConfig) bool { return cfg.EnableDisk }, func() subsystem { return &diskSubsystem{} }},
+	{SubsystemDiskIO, func(cfg config.MetricsConfig) bool { return cfg.EnableDiskIO }, func() subsystem { return &diskIOSubsystem{} }},
+	{SubsystemNetwork, func(cfg config.MetricsConfig) bool { return cfg.EnableNet }, func() subsystem { return &networkSubsystem{} }},
+	{SubsystemProcesses, func(cfg config.MetricsConfig) bool { return cfg.EnableProcesses }, func() subsystem { return &processSubsystem{} }},
+	{SubsystemWatchlist, func(cfg config.MetricsConfig) bool { return len(cfg.Watchlist) > 0 }, func() subsystem { return &watchlistSubsystem{} }},
+}
+
+func init() {
+	for _, b := range builtins {
+		create := b.create
+		name := b.name
+		Register(name, func() MetricSource { return &builtinSource{name: name, sub: create()} })
+	}
+}
+
+// builtinSource yerleşik bir alt sistemi MetricSource olarak sunar. enable_*
+// ile etkinleştirilen alt sistemler snapshot'ın tipli alanlarına yazılır;
+// metrics.sources listesine eklenenler sayısal alanlarını örnek olarak üretir.
+type builtinSource struct {
+	name    string
+	config  config.MetricsConfig // Collector tarafından Init'ten önce atanır
+	history UsageHistory         // Collector tarafından Init'ten önce atanır; yoksa nil
+	sub     subsystem
+}
+
+// Name kaynağın kayıt ismini döndürür
+func (s *builtinSource) Name() string {
+	return s.name
+}
+
+// NewOptions alansız bir struct döndürür; doğrulamada her seçenek bilinmeyen sayılır
+func (s *builtinSource) NewOptions() interface{} {
+	return &struct{}{}
+}
+
+// Init alt sistemi metrics ayarlarıyla başlatır; yerleşik kaynaklar seçenek almaz
+func (s *builtinSource) Init(options map[string]interface{}) error {
+	if len(options) > 0 {
+		return fmt.Errorf("yerleşik kaynak seçenek almaz, metrics ayarlarını kullanın")
+	}
+	if hs, ok := s.sub.(historySubsystem); ok {
+		hs.setHistory(s.history)
+	}
+	s.sub.start(s.config)
+	return nil
+}
+
+// Collect alt sistemi toplar ve sayısal alanlarını gauge örnekleri olarak döndürür
+func (s *builtinSource) Collect(ctx context.Context) ([]Sample, error) {
+	m := &SystemMetrics{}
+	if err := s.sub.collectInto(m); err != nil {
+		return nil, err
+	}
+
+	values := m.Flatten()
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	samples := make([]Sample, 0, len(names))
+	for _, name := range names {
+		samples = append(samples, Sample{Name: name, Value: values[name], Type: SampleGauge})
+	}
+	return samples, nil
+}
+
+// Close yerleşik kaynaklar için bir şey yapmaz
+func (s *builtinSource) Close() error {
+	return nil
+}
@@ -1,9 +1,15 @@
 package metrics
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"path"
+	"runtime"
+	"sync"
 	"time"
 
+	"github.com/karsterr/syswatch-daemon/internal/config"
 	"github.com/karsterr/syswatch-daemon/internal/logger"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/disk"
@@ -11,19 +17,65 @@ import (
 	"github.com/shirou/gopsutil/v3/net"
 )
 
-// SystemMetrics sistem metriklerini içerir
+// Alt sistem isimleri (Errors map'inin anahtarları)
+const (
+	SubsystemCPU     = "cpu"
+	SubsystemHost    = "host"
+	SubsystemMemory  = "memory"
+	SubsystemDisk    = "disk"
+	SubsystemDiskIO  = "disk_io"
+	SubsystemNetwork = "network"
+	
+	SubsystemProcesses = "processes"
+	SubsystemWatchlist = "watchlist"
+
+	// SourceErrorPrefix eklenti kaynaklarının Errors map'indeki anahtar öneki
+	SourceErrorPrefix = "source:"
+)
+
+// SystemMetrics sistem metriklerini içerir.
+// Devre dışı bırakılan veya hata veren alt sistemler nil kalır ve JSON'a yazılmaz.
 type SystemMetrics struct {
-	Timestamp time.Time    `json:"timestamp"`
-	CPU       CPUMetrics   `json:"cpu"`
-	Memory    MemMetrics   `json:"memory"`
-	Disk      DiskMetrics  `json:"disk"`
-	Network   NetMetrics   `json:"network"`
+	Timestamp time.Time               `json:"timestamp"`
+	Host      *HostMetrics            `json:"host,omitempty"`
+	CPU       *CPUMetrics             `json:"cpu,omitempty"`
+	Memory    *MemMetrics             `json:"memory,omitempty"`
+	Disk      *DiskMetrics            `json:"disk,omitempty"`
+	DiskIO    *DiskIOMetrics          `json:"disk_io,omitempty"`
+	Network   *NetMetrics             `json:"network,omitempty"`
+	Processes *ProcessesMetrics       `json:"processes,omitempty"`
+	Watchlist []WatchedProcessMetrics `json:"watchlist,omitempty"`
+
+	// Eklenti kaynaklarının örnekleri (config'deki kaynak ismine göre)
+	Sources map[string][]Sample `json:"sources,omitempty"`
+	
+	// Alt sistem bazında toplama hataları
+	Errors map[string]string `json:"errors,omitempty"`
 }
 
 // CPUMetrics CPU ile ilgili metrikleri içerir
 type CPUMetrics struct {
 	Usage   float64 `json:"usage"`   // CPU kullanım yüzdesi
 	Count   int     `json:"count"`   // CPU çekirdek sayısı
+	
+	// Önceki ölçümden bu yana geçen sürenin yüzde dağılımı
+	User    float64 `json:"user"`
+	Nice    float64 `json:"nice"`
+	System  float64 `json:"system"`
+	Idle    float64 `json:"idle"`
+	Iowait  float64 `json:"iowait"`
+	Irq     float64 `json:"irq"`
+	Softirq float64 `json:"softirq"`
+	Steal   float64 `json:"steal"`
+	Guest   float64 `json:"guest"`
+
+	Cores []CoreMetrics `json:"cores,omitempty"` // Mantıksal çekirdek bazında kullanım
+}
+
+// CoreMetrics tek bir mantıksal çekirdeğin kullanımını içerir
+type CoreMetrics struct {
+	Name  string  `json:"name"`  // cpu0, cpu1, ...
+	Usage float64 `json:"usage"` // Önceki ölçümden bu yana kullanım yüzdesi
 }
 
 // MemMetrics bellek ile ilgili metrikleri içerir
@@ -32,103 +84,242 @@ type MemMetrics struct {
 	Total       uint64  `json:"total"`        // Toplam bellek (bytes)
 	Available   uint64  `json:"available"`    // Kullanılabilir bellek (bytes)
 	Used        uint64  `json:"used"`         // Kullanılan bellek (bytes)
+	Free        uint64  `json:"free"`         // Tamamen boş bellek (bytes)
+	Buffers     uint64  `json:"buffers"`      // Blok cihaz buffer'ları (bytes)
+	Cached      uint64  `json:"cached"`       // Sayfa önbelleği (bytes)
+	Shared      uint64  `json:"shared"`       // Paylaşılan bellek / tmpfs (bytes)
+	Slab        uint64  `json:"slab"`         // Kernel slab (bytes)
+	Dirty       uint64  `json:"dirty"`        // Diske yazılmayı bekleyen (bytes)
+	WriteBack   uint64  `json:"writeback"`    // Şu an diske yazılan (bytes)
+	
+	Swap SwapMetrics `json:"swap"`
+}
+
+// SwapMetrics swap alanı ile ilgili metrikleri içerir
+type SwapMetrics struct {
+	Usage          float64 `json:"usage"`             // Swap kullanım yüzdesi
+	Total          uint64  `json:"total"`             // Toplam swap (bytes)
+	Used           uint64  `json:"used"`              // Kullanılan swap (bytes)
+	Free           uint64  `json:"free"`              // Boş swap (bytes)
+	InBytesPerSec  float64 `json:"in_bytes_per_sec"`  // Swap'tan belleğe okunan
+	OutBytesPerSec float64 `json:"out_bytes_per_sec"` // Bellekten swap'a yazılan
 }
 
 // DiskMetrics disk ile ilgili metrikleri içerir
 type DiskMetrics struct {
-	Usage       float64 `json:"usage"`        // Disk kullanım yüzdesi
-	Total       uint64  `json:"total"`        // Toplam disk alanı (bytes)
-	Used        uint64  `json:"used"`         // Kullanılan disk alanı (bytes)
-	Free        uint64  `json:"free"`         // Boş disk alanı (bytes)
+	Usage       float64 `json:"usage"`        // En dolu dosya sisteminin kullanım yüzdesi
+	Fullest     string  `json:"fullest"`      // En dolu dosya sisteminin mountpoint'i
+	Total       uint64  `json:"total"`        // İzlenen dosya sistemlerinin toplam alanı (bytes)
+	Used        uint64  `json:"used"`         // İzlenen dosya sistemlerinde kullanılan alan (bytes)
+	Free        uint64  `json:"free"`         // İzlenen dosya sistemlerindeki boş alan (bytes)
+	
+	Filesystems []FilesystemMetrics `json:"filesystems"` // Mountpoint bazında detaylar
+}
+
+// FilesystemMetrics tek bir mount edilmiş dosya sisteminin metriklerini içerir
+type FilesystemMetrics struct {
+	Mountpoint  string  `json:"mountpoint"`
+	Device      string  `json:"device"`
+	Fstype      string  `json:"fstype"`
+	Usage       float64 `json:"usage"`        // Kullanım yüzdesi
+	Total       uint64  `json:"total"`        // Toplam alan (bytes)
+	Used        uint64  `json:"used"`         // Kullanılan alan (bytes)
+	Free        uint64  `json:"free"`         // Boş alan (bytes)
+	InodesTotal uint64  `json:"inodes_total"`
+	InodesUsed  uint64  `json:"inodes_used"`
+	InodesFree  uint64  `json:"inodes_free"`
+	InodesUsage float64 `json:"inodes_usage"` // Inode kullanım yüzdesi
+
+	// Doğrusal regresyon tahmini; yeterli geçmiş yoksa nil
+	GrowthBytesPerHour *float64 `json:"growth_bytes_per_hour,omitempty"` // Kullanılan alanın saatlik artışı
+	TimeToFull         *float64 `json:"time_to_full_seconds,omitempty"`  // Tahmini dolma süresi (sadece büyüyen dosya sistemleri)
 }
 
 // NetMetrics ağ ile ilgili metrikleri içerir
 type NetMetrics struct {
-	BytesRecv   uint64 `json:"bytes_recv"`   // Alınan bytes
-	BytesSent   uint64 `json:"bytes_sent"`   // Gönderilen bytes
-	PacketsRecv uint64 `json:"packets_recv"` // Alınan paket sayısı
-	PacketsSent uint64 `json:"packets_sent"` // Gönderilen paket sayısı
+	BytesRecv   uint64 `json:"bytes_recv"`   // Alınan bytes (kümülatif)
+	BytesSent   uint64 `json:"bytes_sent"`   // Gönderilen bytes (kümülatif)
+	PacketsRecv uint64 `json:"packets_recv"` // Alınan paket sayısı (kümülatif)
+	PacketsSent uint64 `json:"packets_sent"` // Gönderilen paket sayısı (kümülatif)
+	
+	RecvBytesPerSec float64 `json:"recv_bytes_per_sec"` // Tüm interface'lerin toplam alma hızı
+	SentBytesPerSec float64 `json:"sent_bytes_per_sec"` // Tüm interface'lerin toplam gönderme hızı
+	
+	Interfaces []InterfaceMetrics `json:"interfaces"` // Interface bazında detaylar
+}
+
+// InterfaceMetrics tek bir ağ interface'inin sayaç ve hızlarını içerir
+type InterfaceMetrics struct {
+	Name        string `json:"name"`
+	BytesRecv   uint64 `json:"bytes_recv"`
+	BytesSent   uint64 `json:"bytes_sent"`
+	PacketsRecv uint64 `json:"packets_recv"`
+	PacketsSent uint64 `json:"packets_sent"`
+	
+	// Önceki ölçümden bu yana saniye başına değerler
+	RecvBytesPerSec   float64 `json:"recv_bytes_per_sec"`
+	SentBytesPerSec   float64 `json:"sent_bytes_per_sec"`
+	RecvPacketsPerSec float64 `json:"recv_packets_per_sec"`
+	SentPacketsPerSec float64 `json:"sent_packets_per_sec"`
+	ErrinPerSec       float64 `json:"errin_per_sec"`
+	ErroutPerSec      float64 `json:"errout_per_sec"`
+	DropinPerSec      float64 `json:"dropin_per_sec"`
+	DropoutPerSec     float64 `json:"dropout_per_sec"`
+}
+
+// Time snapshot'ın toplanma zamanını döndürür
+func (m *SystemMetrics) Time() time.Time {
+	return m.Timestamp
+}
+
+// Age snapshot'ın toplanmasından bu yana geçen süreyi döndürür
+func (m *SystemMetrics) Age() time.Duration {
+	return time.Since(m.Timestamp)
 }
 
 // Collector sistem metriklerini toplayan yapı
 type Collector struct {
-	// Ağ istatistikleri için önceki değerleri sakla
-	prevNetStats map[string]net.IOCountersStat
+	// Kaynakların önceki örnekleri eşzamanlı toplamalarda korunur
+	mu sync.Mutex
+
+	config config.MetricsConfig
+	
+	// Disk doluluk tahmininin beslendiği geçmiş (yoksa tahmin yapılmaz)
+	history UsageHistory
+	
+	// Etkin kaynaklar: enable_* ile açılan yerleşik alt sistemler ve metrics.sources eklentileri
+	sources []activeSource
 }
 
-// NewCollector yeni collector oluşturur
+// NewCollector yeni collector oluşturur (varsayılan config ile)
 func NewCollector() *Collector {
-	return &Collector{
-		prevNetStats: make(map[string]net.IOCountersStat),
-	}
+	return NewCollectorWithConfig(config.Default().Metrics)
+}
+
+// NewCollectorWithConfig belirtilen metrics ayarları ile yeni collector oluşturur
+func NewCollectorWithConfig(cfg config.MetricsConfig) *Collector {
+	return &Collector{config: cfg}
+}
+
+// SetUsageHistory disk doluluk tahmini için geçmiş kaynağını ayarlar; Start'tan önce çağrılmalıdır
+func (c *Collector) SetUsageHistory(h UsageHistory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.history = h
 }
 
 // Start collector'ı başlatır
 func (c *Collector) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	log := logger.GetLogger()
 	log.Info("Metrics collector başlatıldı")
 	
-	// İlk ağ istatistiklerini al
-	netStats, err := net.IOCounters(true)
-	if err == nil {
-		for _, stat := range netStats {
-			c.prevNetStats[stat.Name] = stat
-		}
+	// Kaynaklar başlatılırken oran hesaplayan alt sistemler ilk örneği alır
+	if err := c.startSources(); err != nil {
+		return err
 	}
-	
+
 	return nil
 }
 
 // Stop collector'ı durdurur
 func (c *Collector) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	log := logger.GetLogger()
+	c.closeSources()
 	log.Info("Metrics collector durduruldu")
 }
 
-// CollectAll tüm sistem metriklerini toplar
+// CollectAll etkin tüm sistem metriklerini toplar.
+// Bir alt sistemin hatası diğerlerini etkilemez; hata Errors map'ine yazılır
+// ve kısmi sonuç döner. Error yalnızca etkin alt sistemlerin hepsi başarısız
+// olduğunda döner.
 func (c *Collector) CollectAll() (*SystemMetrics, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	metrics := &SystemMetrics{
 		Timestamp: time.Now(),
 	}
 
-	// CPU metrikleri
-	cpuMetrics, err := c.collectCPU()
-	if err != nil {
-		return nil, fmt.Errorf("CPU metrikleri toplanamadı: %w", err)
+	failed := 0
+	record := func(subsystem string, err error) {
+		if err != nil {
+			failed++
+			if metrics.Errors == nil {
+				metrics.Errors = make(map[string]string)
+			}
+			metrics.Errors[subsystem] = err.Error()
+		}
 	}
-	metrics.CPU = *cpuMetrics
 
-	// Bellek metrikleri
-	memMetrics, err := c.collectMemory()
-	if err != nil {
-		return nil, fmt.Errorf("bellek metrikleri toplanamadı: %w", err)
-	}
-	metrics.Memory = *memMetrics
+	for _, as := range c.sources {
+		// Yerleşik alt sistemler snapshot'ın kendi alanlarına yazar
+		if as.subsystem != nil {
+			record(as.id, as.subsystem.collectInto(metrics))
+			continue
+		}
 
-	// Disk metrikleri
-	diskMetrics, err := c.collectDisk()
-	if err != nil {
-		return nil, fmt.Errorf("disk metrikleri toplanamadı: %w", err)
+		ctx, cancel := context.WithTimeout(context.Background(), as.timeout)
+		samples, err := as.source.Collect(ctx)
+		cancel()
+
+		record(SourceErrorPrefix+as.id, err)
+		if err == nil {
+			if metrics.Sources == nil {
+				metrics.Sources = make(map[string][]Sample)
+			}
+			metrics.Sources[as.id] = samples
+		}
 	}
-	metrics.Disk = *diskMetrics
 
-	// Ağ metrikleri
-	netMetrics, err := c.collectNetwork()
-	if err != nil {
-		return nil, fmt.Errorf("ağ metrikleri toplanamadı: %w", err)
+	if len(c.sources) > 0 && failed == len(c.sources) {
+		return metrics, fmt.Errorf("hiçbir alt sistemden metrik toplanamadı: %v", metrics.Errors)
 	}
-	metrics.Network = *netMetrics
 
 	return metrics, nil
 }
 
-// collectCPU CPU metriklerini toplar
-func (c *Collector) collectCPU() (*CPUMetrics, error) {
-	// CPU kullanım yüzdesi (1 saniye bekleme ile)
-	usage, err := cpu.Percent(time.Second, false)
+// cpuSubsystem CPU kullanımını önceki cpu.Times örneğine göre hesaplar
+type cpuSubsystem struct {
+	prevTimes     cpu.TimesStat
+	prevCoreTimes map[string]cpu.TimesStat
+}
+
+// start ilk CPU örneğini alır (sonraki ölçüm bu değere göre hesaplanır)
+func (s *cpuSubsystem) start(cfg config.MetricsConfig) {
+	s.prevCoreTimes = make(map[string]cpu.TimesStat)
+	if times, err := cpu.Times(false); err == nil && len(times) > 0 {
+		s.prevTimes = times[0]
+	}
+	if cores, err := cpu.Times(true); err == nil {
+		for _, core := range cores {
+			s.prevCoreTimes[core.CPU] = core
+		}
+	}
+}
+
+func (s *cpuSubsystem) collectInto(m *SystemMetrics) error {
+	cpuMetrics, err := s.collect()
+	m.CPU = cpuMetrics
+	return err
+}
+
+// collect CPU metriklerini toplar
+func (s *cpuSubsystem) collect() (*CPUMetrics, error) {
+	// Toplam CPU zamanları (bloklamadan, önceki örnekle karşılaştırılır)
+	times, err := cpu.Times(false)
 	if err != nil {
 		return nil, err
 	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("CPU zaman bilgisi bulunamadı")
+	}
 
 	// CPU çekirdek sayısı
 	count, err := cpu.Counts(true)
@@ -136,73 +327,399 @@ func (c *Collector) collectCPU() (*CPUMetrics, error) {
 		return nil, err
 	}
 
-	var cpuUsage float64
-	if len(usage) > 0 {
-		cpuUsage = usage[0]
+	current := times[0]
+	cpuMetrics := calculateCPUUsage(s.prevTimes, current)
+	cpuMetrics.Count = count
+	s.prevTimes = current
+
+	// Çekirdek bazında kullanım; alınamazsa toplam değerler yine döner
+	if cores, err := cpu.Times(true); err == nil {
+		cpuMetrics.Cores = make([]CoreMetrics, 0, len(cores))
+		for _, core := range cores {
+			usage := calculateCPUUsage(s.prevCoreTimes[core.CPU], core).Usage
+			cpuMetrics.Cores = append(cpuMetrics.Cores, CoreMetrics{Name: core.CPU, Usage: usage})
+			s.prevCoreTimes[core.CPU] = core
+		}
 	}
 
-	return &CPUMetrics{
-		Usage: cpuUsage,
-		Count: count,
-	}, nil
+	return &cpuMetrics, nil
 }
 
-// collectMemory bellek metriklerini toplar
-func (c *Collector) collectMemory() (*MemMetrics, error) {
+// calculateCPUUsage iki cpu.Times örneği arasındaki farktan kullanım yüzdelerini hesaplar
+func calculateCPUUsage(prev, current cpu.TimesStat) CPUMetrics {
+	// Guest süreleri Linux'ta user/nice içinde zaten sayıldığından toplama eklenmez
+	total := func(t cpu.TimesStat) float64 {
+		return t.User + t.Nice + t.System + t.Idle + t.Iowait + t.Irq + t.Softirq + t.Steal
+	}
+
+	delta := total(current) - total(prev)
+	if delta <= 0 {
+		return CPUMetrics{}
+	}
+
+	percent := func(cur, old float64) float64 {
+		d := cur - old
+		if d < 0 {
+			return 0
+		}
+		return d / delta * 100
+	}
+
+	m := CPUMetrics{
+		User:    percent(current.User, prev.User),
+		Nice:    percent(current.Nice, prev.Nice),
+		System:  percent(current.System, prev.System),
+		Idle:    percent(current.Idle, prev.Idle),
+		Iowait:  percent(current.Iowait, prev.Iowait),
+		Irq:     percent(current.Irq, prev.Irq),
+		Softirq: percent(current.Softirq, prev.Softirq),
+		Steal:   percent(current.Steal, prev.Steal),
+		Guest:   percent(current.Guest, prev.Guest),
+	}
+
+	// Idle ve iowait dışındaki her şey meşgul sayılır
+	m.Usage = 100 - m.Idle - m.Iowait
+	if m.Usage < 0 {
+		m.Usage = 0
+	}
+
+	return m
+}
+
+// memorySubsystem bellek ve swap metriklerini toplar; swap hızları için önceki örneği tutar
+type memorySubsystem struct {
+	prevSwap     *mem.SwapMemoryStat
+	prevSwapTime time.Time
+}
+
+// start swap hızları ilk ölçümde sıfır döndüğünden ilk örnek almaz
+func (s *memorySubsystem) start(cfg config.MetricsConfig) {}
+
+func (s *memorySubsystem) collectInto(m *SystemMetrics) error {
+	memMetrics, err := s.collect()
+	m.Memory = memMetrics
+	return err
+}
+
+// collect bellek ve swap metriklerini toplar
+func (s *memorySubsystem) collect() (*MemMetrics, error) {
 	memInfo, err := mem.VirtualMemory()
 	if err != nil {
 		return nil, err
 	}
 
+	metrics := newMemMetrics(memInfo)
+
+	// Swap bilgisi alınamazsa bellek metrikleri yine de döner
+	swapInfo, err := mem.SwapMemory()
+	if err != nil {
+		logger.GetLogger().Debugf("Swap bilgisi alınamadı: %v", err)
+		return metrics, nil
+	}
+
+	now := time.Now()
+	var elapsed float64
+	if s.prevSwap != nil {
+		elapsed = now.Sub(s.prevSwapTime).Seconds()
+	}
+	metrics.Swap = calculateSwap(s.prevSwap, swapInfo, elapsed)
+	s.prevSwap = swapInfo
+	s.prevSwapTime = now
+
+	return metrics, nil
+}
+
+// newMemMetrics mem.VirtualMemory sonucunu bellek metriklerine dönüştürür
+func newMemMetrics(memInfo *mem.VirtualMemoryStat) *MemMetrics {
 	return &MemMetrics{
 		Usage:     memInfo.UsedPercent,
 		Total:     memInfo.Total,
 		Available: memInfo.Available,
 		Used:      memInfo.Used,
-	}, nil
+		Free:      memInfo.Free,
+		Buffers:   memInfo.Buffers,
+		Cached:    memInfo.Cached,
+		Shared:    memInfo.Shared,
+		Slab:      memInfo.Slab,
+		Dirty:     memInfo.Dirty,
+		WriteBack: memInfo.WriteBack,
+	}
 }
 
-// collectDisk disk metriklerini toplar  
-func (c *Collector) collectDisk() (*DiskMetrics, error) {
-	// Ana disk partition'ını al (genellikle "/" veya "C:")
-	var path string
-	if partitions, err := disk.Partitions(false); err == nil && len(partitions) > 0 {
-		path = partitions[0].Mountpoint
-	} else {
-		path = "/" // Linux default
+// calculateSwap swap kullanımını ve önceki örnekten bu yana in/out hızlarını hesaplar.
+// Önceki örnek yoksa (prev nil veya elapsed 0) hızlar sıfır döner.
+func calculateSwap(prev, current *mem.SwapMemoryStat, elapsed float64) SwapMetrics {
+	swap := SwapMetrics{
+		Usage: current.UsedPercent,
+		Total: current.Total,
+		Used:  current.Used,
+		Free:  current.Free,
+	}
+	if prev != nil && elapsed > 0 {
+		swap.InBytesPerSec = float64(counterDelta(prev.Sin, current.Sin)) / elapsed
+		swap.OutBytesPerSec = float64(counterDelta(prev.Sout, current.Sout)) / elapsed
 	}
+	return swap
+}
 
-	diskInfo, err := disk.Usage(path)
-	if err != nil {
-		return nil, err
+// diskSubsystem mount edilmiş dosya sistemlerinin doluluğunu toplar
+type diskSubsystem struct {
+	config  config.MetricsConfig
+	history UsageHistory
+
+	// Dosya sistemi doluluk tahmini (kapalıysa veya geçmiş yoksa nil)
+	forecaster *diskForecaster
+}
+
+func (s *diskSubsystem) setHistory(h UsageHistory) {
+	s.history = h
+}
+
+func (s *diskSubsystem) start(cfg config.MetricsConfig) {
+	s.config = cfg
+	if cfg.DiskForecastWindow > 0 && s.history != nil {
+		window := time.Duration(cfg.DiskForecastWindow) * time.Second
+		s.forecaster = newDiskForecaster(s.history, window, time.Duration(cfg.Interval)*time.Second)
 	}
+}
 
-	return &DiskMetrics{
-		Usage: diskInfo.UsedPercent,
-		Total: diskInfo.Total,
-		Used:  diskInfo.Used,
-		Free:  diskInfo.Free,
-	}, nil
+func (s *diskSubsystem) collectInto(m *SystemMetrics) error {
+	diskMetrics, err := s.collect()
+	m.Disk = diskMetrics
+	return err
 }
 
-// collectNetwork ağ metriklerini toplar
-func (c *Collector) collectNetwork() (*NetMetrics, error) {
-	netStats, err := net.IOCounters(false) // false = toplam tüm interface'ler
+// collect mount edilmiş tüm dosya sistemlerinin metriklerini toplar
+func (s *diskSubsystem) collect() (*DiskMetrics, error) {
+	log := logger.GetLogger()
+
+	partitions, err := disk.Partitions(false)
+	if err != nil || len(partitions) == 0 {
+		// Partition listesi alınamazsa en azından kök dizini izle
+		log.Debugf("Partition listesi alınamadı, varsayılan mountpoint kullanılıyor: %v", err)
+		partitions = []disk.PartitionStat{{Mountpoint: defaultMountpoint()}}
+	}
+
+	seen := make(map[string]bool, len(partitions))
+	filesystems := make([]FilesystemMetrics, 0, len(partitions))
+	var lastErr error
+
+	for _, p := range partitions {
+		// Bind mount'lar aynı mountpoint'i birden fazla kez listeleyebilir
+		if seen[p.Mountpoint] || !s.filesystemEnabled(p) {
+			continue
+		}
+		seen[p.Mountpoint] = true
+
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil {
+			log.Debugf("Dosya sistemi okunamadı (%s): %v", p.Mountpoint, err)
+			lastErr = err
+			continue
+		}
+
+		fstype := p.Fstype
+		if fstype == "" {
+			fstype = usage.Fstype
+		}
+
+		filesystems = append(filesystems, FilesystemMetrics{
+			Mountpoint:  p.Mountpoint,
+			Device:      p.Device,
+			Fstype:      fstype,
+			Usage:       usage.UsedPercent,
+			Total:       usage.Total,
+			Used:        usage.Used,
+			Free:        usage.Free,
+			InodesTotal: usage.InodesTotal,
+			InodesUsed:  usage.InodesUsed,
+			InodesFree:  usage.InodesFree,
+			InodesUsage: usage.InodesUsedPercent,
+		})
+	}
+
+	if len(filesystems) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	if s.forecaster != nil {
+		s.forecaster.update(time.Now(), filesystems)
+	}
+
+	return summarizeFilesystems(filesystems), nil
+}
+
+// filesystemEnabled partition'ın mountpoint ve fstype filtrelerine göre izlenip izlenmeyeceğini belirler
+func (s *diskSubsystem) filesystemEnabled(p disk.PartitionStat) bool {
+	if !matchFilter(p.Mountpoint, s.config.DiskInclude, s.config.DiskExclude) {
+		return false
+	}
+	// Fstype bilinmiyorsa (fallback durumu) fstype filtresi uygulanmaz
+	if p.Fstype == "" {
+		return true
+	}
+	return matchFilter(p.Fstype, s.config.DiskFstypeInclude, s.config.DiskFstypeExclude)
+}
+
+// summarizeFilesystems dosya sistemi listesinden toplam ve en dolu değerleri hesaplar
+func summarizeFilesystems(filesystems []FilesystemMetrics) *DiskMetrics {
+	metrics := &DiskMetrics{Filesystems: filesystems}
+
+	for _, fs := range filesystems {
+		metrics.Total += fs.Total
+		metrics.Used += fs.Used
+		metrics.Free += fs.Free
+
+		if metrics.Fullest == "" || fs.Usage > metrics.Usage {
+			metrics.Usage = fs.Usage
+			metrics.Fullest = fs.Mountpoint
+		}
+	}
+
+	return metrics
+}
+
+// defaultMountpoint platforma göre varsayılan kök mountpoint'i döndürür
+func defaultMountpoint() string {
+	if runtime.GOOS == "windows" {
+		return "C:"
+	}
+	return "/" // Linux default
+}
+
+// networkSubsystem interface sayaçlarından ağ hızlarını hesaplar
+type networkSubsystem struct {
+	config config.MetricsConfig
+
+	// Ağ istatistikleri için önceki değerler
+	prevStats map[string]net.IOCountersStat
+	prevTime  time.Time
+}
+
+// start ilk ağ istatistiklerini alır
+func (s *networkSubsystem) start(cfg config.MetricsConfig) {
+	s.config = cfg
+	s.prevStats = make(map[string]net.IOCountersStat)
+	s.collect()
+}
+
+func (s *networkSubsystem) collectInto(m *SystemMetrics) error {
+	netMetrics, err := s.collect()
+	m.Network = netMetrics
+	return err
+}
+
+// collect ağ metriklerini toplar
+func (s *networkSubsystem) collect() (*NetMetrics, error) {
+	netStats, err := net.IOCounters(true) // true = interface bazında
 	if err != nil {
 		return nil, err
 	}
 
-	if len(netStats) == 0 {
-		return &NetMetrics{}, nil
+	now := time.Now()
+	var elapsed float64
+	if !s.prevTime.IsZero() {
+		elapsed = now.Sub(s.prevTime).Seconds()
+	}
+
+	// Filtreye uymayan interface'leri çıkar
+	current := make([]net.IOCountersStat, 0, len(netStats))
+	for _, stat := range netStats {
+		if s.interfaceEnabled(stat.Name) {
+			current = append(current, stat)
+		}
+	}
+
+	interfaces := calculateNetRates(s.prevStats, current, elapsed)
+
+	// Kaybolan interface'ler bir sonraki ölçümde hesaba katılmasın diye map yeniden kurulur
+	s.prevStats = make(map[string]net.IOCountersStat, len(current))
+	for _, stat := range current {
+		s.prevStats[stat.Name] = stat
+	}
+	s.prevTime = now
+
+	metrics := &NetMetrics{Interfaces: interfaces}
+	for _, iface := range interfaces {
+		metrics.BytesRecv += iface.BytesRecv
+		metrics.BytesSent += iface.BytesSent
+		metrics.PacketsRecv += iface.PacketsRecv
+		metrics.PacketsSent += iface.PacketsSent
+		metrics.RecvBytesPerSec += iface.RecvBytesPerSec
+		metrics.SentBytesPerSec += iface.SentBytesPerSec
+	}
+
+	return metrics, nil
+}
+
+// interfaceEnabled interface'in include/exclude desenlerine göre izlenip izlenmeyeceğini belirler
+func (s *networkSubsystem) interfaceEnabled(name string) bool {
+	return matchFilter(name, s.config.NetInclude, s.config.NetExclude)
+}
+
+// matchFilter glob desenlerine göre include/exclude kontrolü yapar.
+// Include listesi boşsa her şey dahildir; exclude her zaman önceliklidir.
+func matchFilter(name string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
 	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
 
-	// İlk (toplam) istatistikleri al
-	stat := netStats[0]
+// calculateNetRates önceki ve şimdiki sayaçlardan interface bazında hızları hesaplar.
+// Önceki örneği olmayan (yeni eklenen) interface'ler için hızlar sıfır döner.
+func calculateNetRates(prev map[string]net.IOCountersStat, current []net.IOCountersStat, elapsed float64) []InterfaceMetrics {
+	result := make([]InterfaceMetrics, 0, len(current))
 
-	return &NetMetrics{
-		BytesRecv:   stat.BytesRecv,
-		BytesSent:   stat.BytesSent,
-		PacketsRecv: stat.PacketsRecv,
-		PacketsSent: stat.PacketsSent,
-	}, nil
-}
\ No newline at end of file
+	for _, stat := range current {
+		iface := InterfaceMetrics{
+			Name:        stat.Name,
+			BytesRecv:   stat.BytesRecv,
+			BytesSent:   stat.BytesSent,
+			PacketsRecv: stat.PacketsRecv,
+			PacketsSent: stat.PacketsSent,
+		}
+
+		old, ok := prev[stat.Name]
+		if ok && elapsed > 0 {
+			rate := func(oldVal, newVal uint64) float64 {
+				return float64(counterDelta(oldVal, newVal)) / elapsed
+			}
+			iface.RecvBytesPerSec = rate(old.BytesRecv, stat.BytesRecv)
+			iface.SentBytesPerSec = rate(old.BytesSent, stat.BytesSent)
+			iface.RecvPacketsPerSec = rate(old.PacketsRecv, stat.PacketsRecv)
+			iface.SentPacketsPerSec = rate(old.PacketsSent, stat.PacketsSent)
+			iface.ErrinPerSec = rate(old.Errin, stat.Errin)
+			iface.ErroutPerSec = rate(old.Errout, stat.Errout)
+			iface.DropinPerSec = rate(old.Dropin, stat.Dropin)
+			iface.DropoutPerSec = rate(old.Dropout, stat.Dropout)
+		}
+
+		result = append(result, iface)
+	}
+
+	return result
+}
+
+// counterDelta iki sayaç değeri arasındaki farkı hesaplar.
+// Sayaç geriye gittiyse 32-bit taşma varsayılır; önceki değer 32-bit
+// sınırını aşıyorsa sayaç sıfırlanmış kabul edilir.
+func counterDelta(prev, current uint64) uint64 {
+	if current >= prev {
+		return current - prev
+	}
+	if prev <= math.MaxUint32 {
+		return math.MaxUint32 - prev + current + 1
+	}
+	return current
+}
@@ -1,7 +1,18 @@
 package metrics
 
 import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
 )
 
 func TestNewCollector(t *testing.T) {
@@ -11,8 +22,8 @@ func TestNewCollector(t *testing.T) {
 		t.Fatal("NewCollector() returned nil")
 	}
 	
-	if collector.prevNetStats == nil {
-		t.Error("prevNetStats map should be initialized")
+	if len(collector.sources) != 0 {
+		t.Error("Sources should only be created on Start()")
 	}
 }
 
@@ -90,27 +101,441 @@ func TestCollectAll(t *testing.T) {
 	}
 }
 
+func TestCollectAllDisabledSubsystems(t *testing.T) {
+	cfg := config.Default().Metrics
+	cfg.EnableCPU = false
+	cfg.EnableDisk = false
+	cfg.EnableNet = false
+	
+	collector := NewCollectorWithConfig(cfg)
+	if err := collector.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	defer collector.Stop()
+	
+	metrics, err := collector.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll() failed: %v", err)
+	}
+	
+	// Devre dışı alt sistemler sorgulanmamalı
+	if metrics.CPU != nil || metrics.Disk != nil || metrics.Network != nil {
+		t.Error("Disabled subsystems should be nil")
+	}
+	
+	if metrics.Memory == nil {
+		t.Error("Enabled memory subsystem should be collected")
+	}
+}
+
+func TestCalculateCPUUsage(t *testing.T) {
+	prev := cpu.TimesStat{User: 100, System: 50, Idle: 800, Iowait: 40, Steal: 10}
+	current := cpu.TimesStat{User: 130, System: 60, Idle: 850, Iowait: 50, Steal: 10}
+	
+	// Delta toplamı: 30 + 10 + 50 + 10 = 100
+	m := calculateCPUUsage(prev, current)
+	
+	expected := map[string][2]float64{
+		"user":   {m.User, 30},
+		"system": {m.System, 10},
+		"idle":   {m.Idle, 50},
+		"iowait": {m.Iowait, 10},
+		"steal":  {m.Steal, 0},
+		"usage":  {m.Usage, 40},
+	}
+	for name, v := range expected {
+		if math.Abs(v[0]-v[1]) > 0.001 {
+			t.Errorf("Expected %s %.2f, got %.2f", name, v[1], v[0])
+		}
+	}
+	
+	// Zaman ilerlemediyse sıfır dönmeli
+	if zero := calculateCPUUsage(current, current); zero.Usage != 0 {
+		t.Errorf("Expected zero usage for identical samples, got %.2f", zero.Usage)
+	}
+}
+
+func TestCalculateNetRates(t *testing.T) {
+	prev := map[string]net.IOCountersStat{
+		"eth0": {Name: "eth0", BytesRecv: 1000, BytesSent: 500, PacketsRecv: 10, Errin: 1},
+		"gone0": {Name: "gone0", BytesRecv: 42},
+	}
+	current := []net.IOCountersStat{
+		{Name: "eth0", BytesRecv: 3000, BytesSent: 1500, PacketsRecv: 30, Errin: 3},
+		{Name: "new0", BytesRecv: 999},
+	}
+	
+	rates := calculateNetRates(prev, current, 2)
+	if len(rates) != 2 {
+		t.Fatalf("Expected 2 interfaces, got %d", len(rates))
+	}
+	
+	eth0 := rates[0]
+	if eth0.RecvBytesPerSec != 1000 || eth0.SentBytesPerSec != 500 {
+		t.Errorf("Unexpected eth0 byte rates: recv=%.1f sent=%.1f", eth0.RecvBytesPerSec, eth0.SentBytesPerSec)
+	}
+	if eth0.RecvPacketsPerSec != 10 || eth0.ErrinPerSec != 1 {
+		t.Errorf("Unexpected eth0 packet/error rates: packets=%.1f errin=%.1f", eth0.RecvPacketsPerSec, eth0.ErrinPerSec)
+	}
+	
+	// Yeni interface için önceki örnek olmadığından hız sıfır olmalı
+	if rates[1].Name != "new0" || rates[1].RecvBytesPerSec != 0 {
+		t.Errorf("Expected zero rate for new interface, got %+v", rates[1])
+	}
+}
+
+func TestCounterDelta(t *testing.T) {
+	if d := counterDelta(100, 150); d != 50 {
+		t.Errorf("Expected delta 50, got %d", d)
+	}
+	
+	// 32-bit taşma
+	if d := counterDelta(math.MaxUint32-9, 5); d != 15 {
+		t.Errorf("Expected wraparound delta 15, got %d", d)
+	}
+	
+	// 64-bit sayaç sıfırlanması
+	if d := counterDelta(math.MaxUint32+1000, 7); d != 7 {
+		t.Errorf("Expected reset delta 7, got %d", d)
+	}
+}
+
+func TestMatchFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected bool
+	}{
+		{"eth0", nil, []string{"lo"}, true},
+		{"lo", nil, []string{"lo"}, false},
+		{"veth1234", nil, []string{"lo", "veth*"}, false},
+		{"eth0", []string{"en*"}, nil, false},
+		{"enp3s0", []string{"en*"}, nil, true},
+		{"enp3s0", []string{"en*"}, []string{"enp3*"}, false},
+	}
+	
+	for _, tc := range testCases {
+		if got := matchFilter(tc.name, tc.include, tc.exclude); got != tc.expected {
+			t.Errorf("matchFilter(%q, %v, %v) = %v, expected %v", tc.name, tc.include, tc.exclude, got, tc.expected)
+		}
+	}
+}
+
+func TestCalculateDiskIO(t *testing.T) {
+	prev := map[string]disk.IOCountersStat{
+		"sda": {ReadCount: 100, WriteCount: 50, ReadBytes: 4096, WriteBytes: 8192, ReadTime: 200, WriteTime: 100, IoTime: 1000, WeightedIO: 1000},
+	}
+	current := map[string]disk.IOCountersStat{
+		"sda": {ReadCount: 120, WriteCount: 60, ReadBytes: 8192, WriteBytes: 16384, ReadTime: 300, WriteTime: 150, IoTime: 1500, WeightedIO: 2000},
+		"sdb": {ReadCount: 5},
+	}
+	
+	// 2 saniyelik aralık
+	devices := calculateDiskIO(prev, current, 2)
+	if len(devices) != 2 || devices[0].Name != "sda" || devices[1].Name != "sdb" {
+		t.Fatalf("Expected sorted devices [sda sdb], got %+v", devices)
+	}
+	
+	sda := devices[0]
+	checks := map[string][2]float64{
+		"read_bytes_per_sec":  {sda.ReadBytesPerSec, 2048},
+		"write_bytes_per_sec": {sda.WriteBytesPerSec, 4096},
+		"read_ops_per_sec":    {sda.ReadOpsPerSec, 10},
+		"write_ops_per_sec":   {sda.WriteOpsPerSec, 5},
+		"read_await_ms":       {sda.ReadAwaitMs, 5},
+		"write_await_ms":      {sda.WriteAwaitMs, 5},
+		"await_ms":            {sda.AwaitMs, 5},
+		"queue_depth":         {sda.QueueDepth, 0.5},
+		"utilization":         {sda.Utilization, 25},
+	}
+	for name, v := range checks {
+		if math.Abs(v[0]-v[1]) > 0.001 {
+			t.Errorf("Expected %s %.3f, got %.3f", name, v[1], v[0])
+		}
+	}
+	
+	// Yeni cihaz için oranlar sıfır olmalı
+	if devices[1].ReadOpsPerSec != 0 {
+		t.Errorf("Expected zero rate for new device, got %.2f", devices[1].ReadOpsPerSec)
+	}
+}
+
+func TestAggregateDiskIO(t *testing.T) {
+	prev := map[string]disk.IOCountersStat{
+		"sda":  {},
+		"sda1": {},
+		"dm-0": {},
+		"sdb":  {},
+	}
+	// sda1 üzerindeki dm-0'a yapılan yazma sda1 ve sda'da da görünür
+	current := map[string]disk.IOCountersStat{
+		"sda":  {ReadBytes: 4096, WriteBytes: 8192},
+		"sda1": {ReadBytes: 4096, WriteBytes: 8192},
+		"dm-0": {ReadBytes: 4096, WriteBytes: 8192},
+		"sdb":  {ReadBytes: 2048},
+	}
+	stacked := map[string]bool{"sda1": true, "dm-0": true}
+	wholeDisk := func(name string) bool { return !stacked[name] }
+
+	metrics := aggregateDiskIO(calculateDiskIO(prev, current, 1), wholeDisk)
+	if metrics.ReadBytesPerSec != 6144 || metrics.WriteBytesPerSec != 8192 {
+		t.Errorf("Expected totals of whole disks only (6144/8192), got %.0f/%.0f", metrics.ReadBytesPerSec, metrics.WriteBytesPerSec)
+	}
+	if len(metrics.Devices) != 4 {
+		t.Errorf("Expected partitions to stay in the device list, got %d devices", len(metrics.Devices))
+	}
+}
+
+func TestNewMemMetrics(t *testing.T) {
+	const gib = 1 << 30
+	m := newMemMetrics(&mem.VirtualMemoryStat{
+		Total: 16 * gib, Available: 10 * gib, Used: 5 * gib, Free: 2 * gib, UsedPercent: 31.25,
+		Buffers: 1 * gib, Cached: 8 * gib, Shared: gib / 2, Slab: gib / 4, Dirty: 4096, WriteBack: 1024,
+	})
+
+	expected := map[string][2]uint64{
+		"total":     {m.Total, 16 * gib},
+		"available": {m.Available, 10 * gib},
+		"used":      {m.Used, 5 * gib},
+		"free":      {m.Free, 2 * gib},
+		"buffers":   {m.Buffers, 1 * gib},
+		"cached":    {m.Cached, 8 * gib},
+		"shared":    {m.Shared, gib / 2},
+		"slab":      {m.Slab, gib / 4},
+		"dirty":     {m.Dirty, 4096},
+		"writeback": {m.WriteBack, 1024},
+	}
+	for name, v := range expected {
+		if v[0] != v[1] {
+			t.Errorf("Expected %s %d, got %d", name, v[1], v[0])
+		}
+	}
+	if m.Usage != 31.25 {
+		t.Errorf("Expected usage 31.25, got %.2f", m.Usage)
+	}
+}
+
+func TestCalculateSwap(t *testing.T) {
+	prev := &mem.SwapMemoryStat{Sin: 1000, Sout: 4000}
+	current := &mem.SwapMemoryStat{Total: 4096, Used: 1024, Free: 3072, UsedPercent: 25, Sin: 3000, Sout: 4000}
+
+	testCases := []struct {
+		name    string
+		prev    *mem.SwapMemoryStat
+		elapsed float64
+		wantIn  float64
+		wantOut float64
+	}{
+		{"rates over interval", prev, 2, 1000, 0},
+		{"first sample", nil, 0, 0, 0},
+		{"no elapsed time", prev, 0, 0, 0},
+		{"counter wraparound", &mem.SwapMemoryStat{Sin: math.MaxUint32 - 999, Sout: 4000}, 4, 1000, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			swap := calculateSwap(tc.prev, current, tc.elapsed)
+			if swap.Total != 4096 || swap.Used != 1024 || swap.Free != 3072 || swap.Usage != 25 {
+				t.Errorf("Unexpected swap usage: %+v", swap)
+			}
+			if swap.InBytesPerSec != tc.wantIn || swap.OutBytesPerSec != tc.wantOut {
+				t.Errorf("Expected in=%.1f out=%.1f, got in=%.1f out=%.1f", tc.wantIn, tc.wantOut, swap.InBytesPerSec, swap.OutBytesPerSec)
+			}
+		})
+	}
+}
+
+func TestTopProcesses(t *testing.T) {
+	candidates := []processCandidate{
+		{metrics: ProcessMetrics{PID: 1, CPUPercent: 5, RSS: 300}},
+		{metrics: ProcessMetrics{PID: 2, CPUPercent: 50, RSS: 100}},
+		{metrics: ProcessMetrics{PID: 3, CPUPercent: 20, RSS: 200}},
+	}
+	
+	byCPU := topProcesses(candidates, ProcessSortCPU, 2)
+	if len(byCPU) != 2 || byCPU[0].metrics.PID != 2 || byCPU[1].metrics.PID != 3 {
+		t.Errorf("Unexpected top by CPU: %+v", byCPU)
+	}
+	
+	byMem := topProcesses(candidates, ProcessSortMemory, 0)
+	if len(byMem) != 3 || byMem[0].metrics.PID != 1 {
+		t.Errorf("Unexpected top by memory: %+v", byMem)
+	}
+}
+
+func TestRedactCmdline(t *testing.T) {
+	args := []string{"/usr/bin/app", "--password=hunter2", "--api-token", "abc", "--verbose", "--port", "8080"}
+	expected := []string{"/usr/bin/app", "--password=***", "--api-token", "***", "--verbose", "--port", "8080"}
+	
+	got := redactCmdline(args)
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Arg %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+	
+	// Orijinal slice değişmemeli
+	if args[1] != "--password=hunter2" {
+		t.Error("redactCmdline should not modify its input")
+	}
+}
+
+func TestWatchlistPidfile(t *testing.T) {
+	pidfile := filepath.Join(t.TempDir(), "test.pid")
+	if err := os.WriteFile(pidfile, []byte(fmt.Sprintf("%d\n", os.Getpid())), 0644); err != nil {
+		t.Fatalf("Failed to write pidfile: %v", err)
+	}
+	
+	cfg := config.Default().Metrics
+	cfg.Watchlist = []config.ProcessWatchConfig{{Name: "self", Pidfile: pidfile}}
+	watch := &watchlistSubsystem{}
+	watch.start(cfg)
+	
+	watchlist, err := watch.collect()
+	if err != nil {
+		t.Fatalf("collect() failed: %v", err)
+	}
+	if len(watchlist) != 1 || !watchlist[0].Up || watchlist[0].PID != int32(os.Getpid()) {
+		t.Fatalf("Expected test process to be up, got %+v", watchlist)
+	}
+	
+	// Pidfile kaldırıldığında process kapalı görünmeli
+	os.Remove(pidfile)
+	watchlist, _ = watch.collect()
+	if watchlist[0].Up {
+		t.Error("Expected process to be reported down without pidfile")
+	}
+	
+	// Aynı process geri geldiğinde restart sayılır (PID ve başlama zamanı önceki ölçümle karşılaştırılır)
+	os.WriteFile(pidfile, []byte(fmt.Sprintf("%d", os.Getpid())), 0644)
+	watchlist, _ = watch.collect()
+	if !watchlist[0].Up || watchlist[0].Restarts != 1 {
+		t.Errorf("Expected process up with 1 restart, got %+v", watchlist[0])
+	}
+}
+
+func TestSummarizeFilesystems(t *testing.T) {
+	filesystems := []FilesystemMetrics{
+		{Mountpoint: "/", Usage: 40, Total: 100, Used: 40, Free: 60},
+		{Mountpoint: "/data", Usage: 90, Total: 1000, Used: 900, Free: 100},
+		{Mountpoint: "/boot", Usage: 20, Total: 10, Used: 2, Free: 8},
+	}
+	
+	summary := summarizeFilesystems(filesystems)
+	
+	if summary.Fullest != "/data" || summary.Usage != 90 {
+		t.Errorf("Expected fullest /data at 90%%, got %s at %.1f%%", summary.Fullest, summary.Usage)
+	}
+	
+	if summary.Total != 1110 || summary.Used != 942 || summary.Free != 168 {
+		t.Errorf("Unexpected totals: total=%d used=%d free=%d", summary.Total, summary.Used, summary.Free)
+	}
+	
+	if len(summary.Filesystems) != 3 {
+		t.Errorf("Expected 3 filesystems, got %d", len(summary.Filesystems))
+	}
+}
+
 func TestSystemMetricsStructure(t *testing.T) {
 	// Yapısal test - metrik yapısının doğru tanımlandığından emin ol
 	var metrics SystemMetrics
 	
-	// JSON tag'lerinin varlığını kontrol et (reflection ile)
-	// Bu test compile time'da yapı doğruluğunu kontrol eder
+	// Alt sistemler pointer olduğundan başlangıçta nil olmalı (JSON'da omitempty)
+	if metrics.CPU != nil || metrics.Memory != nil || metrics.Disk != nil || metrics.Network != nil {
+		t.Error("Zero value subsystems should be nil")
+	}
 	
-	if metrics.CPU.Usage == 0 && metrics.CPU.Count == 0 {
-		// Başlangıç değerleri sıfır olmalı
+	if metrics.Errors != nil {
+		t.Error("Zero value errors map should be nil")
 	}
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore()
 	
-	if metrics.Memory.Total == 0 && metrics.Memory.Used == 0 {
-		// Başlangıç değerleri sıfır olmalı  
+	if store.Latest() != nil {
+		t.Error("Empty store should return nil")
 	}
 	
-	if metrics.Disk.Total == 0 && metrics.Disk.Used == 0 {
-		// Başlangıç değerleri sıfır olmalı
+	first := &SystemMetrics{Timestamp: time.Now()}
+	second := &SystemMetrics{Timestamp: time.Now()}
+	store.Publish(first)
+	store.Publish(second)
+	
+	if store.Latest() != second {
+		t.Error("Latest() should return the most recently published snapshot")
 	}
+}
+
+func TestStoreSubscribe(t *testing.T) {
+	store := NewStore()
+	fast := store.Subscribe(2)
+	slow := store.Subscribe(1)
+	
+	first := &SystemMetrics{Timestamp: time.Now()}
+	second := &SystemMetrics{Timestamp: time.Now()}
+	store.Publish(first)
+	<-fast.C
 	
-	if metrics.Network.BytesRecv == 0 && metrics.Network.BytesSent == 0 {
-		// Başlangıç değerleri sıfır olmalı
+	// slow abonesi ilk snapshot'ı okumadığı için ikincide düşürülmeli
+	store.Publish(second)
+	if got := <-fast.C; got != second {
+		t.Error("Subscriber should receive the published snapshot")
+	}
+	if !slow.Dropped() {
+		t.Error("Slow subscriber should be dropped instead of blocking Publish")
+	}
+	if _, ok := <-slow.C; !ok {
+		t.Error("Dropped subscriber should still drain buffered snapshot")
+	}
+	if _, ok := <-slow.C; ok {
+		t.Error("Dropped subscriber channel should be closed")
+	}
+	
+	fast.Close()
+	fast.Close()
+	if _, ok := <-fast.C; ok {
+		t.Error("Closed subscription channel should be closed")
+	}
+	if fast.Dropped() {
+		t.Error("Closed subscription should not be reported as dropped")
+	}
+}
+
+func TestSystemMetricsValue(t *testing.T) {
+	m := &SystemMetrics{
+		CPU: &CPUMetrics{Usage: 42.5},
+		Disk: &DiskMetrics{Filesystems: []FilesystemMetrics{
+			{Mountpoint: "/", Usage: 10},
+			{Mountpoint: "/data", Usage: 90},
+		}},
+		Watchlist: []WatchedProcessMetrics{{Name: "nginx", Up: true}},
+		Sources:   map[string][]Sample{"gpu": {{Name: "temperature", Value: 70}}},
+	}
+	
+	testCases := map[string]float64{
+		"cpu.usage":                    42.5,
+		"disk.filesystems./data.usage": 90,
+		"watchlist.nginx.up":           1,
+		"sources.gpu.temperature":      70,
+	}
+	for path, expected := range testCases {
+		v, ok := m.Value(path)
+		if !ok || v != expected {
+			t.Errorf("Value(%q) = %v, %v; expected %v", path, v, ok, expected)
+		}
+	}
+	
+	// Olmayan ve toplanmamış alanlar bulunamamalı
+	for _, path := range []string{"memory.usage", "cpu.nope", "disk.filesystems./missing.usage"} {
+		if _, ok := m.Value(path); ok {
+			t.Errorf("Value(%q) should not be found", path)
+		}
+	}
+	
+	if fields := m.Fields(); !containsString(fields, "disk.filesystems./.usage") {
+		t.Errorf("Fields() should include filesystem paths, got %v", fields)
 	}
 }
 
@@ -133,27 +558,29 @@ func BenchmarkCollectAll(b *testing.B) {
 }
 
 func BenchmarkCollectCPU(b *testing.B) {
-	collector := NewCollector()
+	cpuSub := &cpuSubsystem{}
+	cpuSub.start(config.Default().Metrics)
 	
 	b.ResetTimer()
 	
 	for i := 0; i < b.N; i++ {
-		_, err := collector.collectCPU()
+		_, err := cpuSub.collect()
 		if err != nil {
-			b.Fatalf("collectCPU() failed: %v", err)
+			b.Fatalf("collect() failed: %v", err)
 		}
 	}
 }
 
 func BenchmarkCollectMemory(b *testing.B) {
-	collector := NewCollector()
-	
+	memSub := &memorySubsystem{}
+	memSub.start(config.Default().Metrics)
+
 	b.ResetTimer()
-	
+
 	for i := 0; i < b.N; i++ {
-		_, err := collector.collectMemory()
+		_, err := memSub.collect()
 		if err != nil {
-			b.Fatalf("collectMemory() failed: %v", err)
+			b.Fatalf("collect() failed: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
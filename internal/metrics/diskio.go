@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// DiskIOMetrics blok cihaz I/O metriklerini içerir
+type DiskIOMetrics struct {
+	// Toplamlara sadece tüm diskler eklenir; bölümler (sda1) ve device-mapper
+	// cihazları (dm-0) aynı I/O'yu tekrar saydığından hariç tutulur
+	ReadBytesPerSec  float64 `json:"read_bytes_per_sec"`  // Tüm disklerin toplam okuma hızı
+	WriteBytesPerSec float64 `json:"write_bytes_per_sec"` // Tüm disklerin toplam yazma hızı
+	
+	Devices []DeviceIOMetrics `json:"devices"` // Cihaz bazında detaylar
+}
+
+// DeviceIOMetrics tek bir blok cihazın I/O metriklerini içerir
+type DeviceIOMetrics struct {
+	Name       string `json:"name"`
+	ReadBytes  uint64 `json:"read_bytes"`  // Okunan bytes (kümülatif)
+	WriteBytes uint64 `json:"write_bytes"` // Yazılan bytes (kümülatif)
+	ReadCount  uint64 `json:"read_count"`  // Okuma işlemi sayısı (kümülatif)
+	WriteCount uint64 `json:"write_count"` // Yazma işlemi sayısı (kümülatif)
+	
+	// Önceki ölçümden bu yana hesaplanan değerler
+	ReadBytesPerSec  float64 `json:"read_bytes_per_sec"`
+	WriteBytesPerSec float64 `json:"write_bytes_per_sec"`
+	ReadOpsPerSec    float64 `json:"read_ops_per_sec"`
+	WriteOpsPerSec   float64 `json:"write_ops_per_sec"`
+	ReadAwaitMs      float64 `json:"read_await_ms"`  // Ortalama okuma bekleme süresi
+	WriteAwaitMs     float64 `json:"write_await_ms"` // Ortalama yazma bekleme süresi
+	AwaitMs          float64 `json:"await_ms"`       // Tüm işlemler için ortalama bekleme süresi
+	QueueDepth       float64 `json:"queue_depth"`    // Ortalama kuyruk uzunluğu (aqu-sz)
+	Utilization      float64 `json:"utilization"`    // Cihazın meşgul olduğu sürenin yüzdesi
+	InProgress       uint64  `json:"in_progress"`    // Anlık devam eden I/O sayısı
+}
+
+// diskIOSubsystem blok cihaz sayaçlarından I/O metriklerini hesaplar
+type diskIOSubsystem struct {
+	config config.MetricsConfig
+
+	// Disk I/O sayaçlarının önceki değerleri
+	prev     map[string]disk.IOCountersStat
+	prevTime time.Time
+}
+
+// start ilk disk I/O sayaçlarını alır (sonuç atılır, sadece önceki değerler kaydedilir)
+func (s *diskIOSubsystem) start(cfg config.MetricsConfig) {
+	s.config = cfg
+	s.collect()
+}
+
+func (s *diskIOSubsystem) collectInto(m *SystemMetrics) error {
+	diskIOMetrics, err := s.collect()
+	m.DiskIO = diskIOMetrics
+	return err
+}
+
+// collect blok cihazların I/O metriklerini önceki örnekle karşılaştırarak toplar
+func (s *diskIOSubsystem) collect() (*DiskIOMetrics, error) {
+	counters, err := disk.IOCounters()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var elapsed float64
+	if !s.prevTime.IsZero() {
+		elapsed = now.Sub(s.prevTime).Seconds()
+	}
+
+	// Filtreye uymayan cihazları çıkar
+	current := make(map[string]disk.IOCountersStat, len(counters))
+	for name, stat := range counters {
+		if matchFilter(name, s.config.DiskIOInclude, s.config.DiskIOExclude) {
+			current[name] = stat
+		}
+	}
+
+	devices := calculateDiskIO(s.prev, current, elapsed)
+	s.prev = current
+	s.prevTime = now
+
+	return aggregateDiskIO(devices, isWholeDisk), nil
+}
+
+// sysBlockDir blok cihazların sysfs dizini
+const sysBlockDir = "/sys/class/block"
+
+// isWholeDisk cihazın bölüm veya device-mapper cihazı olmadığını döndürür.
+// Bölümlerin sysfs dizininde "partition" dosyası bulunur.
+func isWholeDisk(name string) bool {
+	if strings.HasPrefix(name, "dm-") {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(sysBlockDir, name, "partition"))
+	return err != nil
+}
+
+// aggregateDiskIO cihaz listesinden toplam okuma/yazma hızlarını hesaplar.
+// Aynı I/O diskte, bölümünde ve üzerindeki dm cihazında tekrar sayıldığından
+// toplamlara sadece wholeDisk'in kabul ettiği cihazlar eklenir.
+func aggregateDiskIO(devices []DeviceIOMetrics, wholeDisk func(name string) bool) *DiskIOMetrics {
+	metrics := &DiskIOMetrics{Devices: devices}
+	for _, dev := range devices {
+		if !wholeDisk(dev.Name) {
+			continue
+		}
+		metrics.ReadBytesPerSec += dev.ReadBytesPerSec
+		metrics.WriteBytesPerSec += dev.WriteBytesPerSec
+	}
+	return metrics
+}
+
+// calculateDiskIO önceki ve şimdiki sayaçlardan cihaz bazında I/O metriklerini hesaplar.
+// Sonuç cihaz ismine göre sıralıdır; önceki örneği olmayan cihazlar için oranlar sıfır döner.
+func calculateDiskIO(prev, current map[string]disk.IOCountersStat, elapsed float64) []DeviceIOMetrics {
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]DeviceIOMetrics, 0, len(names))
+	for _, name := range names {
+		stat := current[name]
+		dev := DeviceIOMetrics{
+			Name:       name,
+			ReadBytes:  stat.ReadBytes,
+			WriteBytes: stat.WriteBytes,
+			ReadCount:  stat.ReadCount,
+			WriteCount: stat.WriteCount,
+			InProgress: stat.IopsInProgress,
+		}
+
+		old, ok := prev[name]
+		if ok && elapsed > 0 {
+			reads := counterDelta(old.ReadCount, stat.ReadCount)
+			writes := counterDelta(old.WriteCount, stat.WriteCount)
+			readTime := counterDelta(old.ReadTime, stat.ReadTime)
+			writeTime := counterDelta(old.WriteTime, stat.WriteTime)
+			elapsedMs := elapsed * 1000
+
+			dev.ReadBytesPerSec = float64(counterDelta(old.ReadBytes, stat.ReadBytes)) / elapsed
+			dev.WriteBytesPerSec = float64(counterDelta(old.WriteBytes, stat.WriteBytes)) / elapsed
+			dev.ReadOpsPerSec = float64(reads) / elapsed
+			dev.WriteOpsPerSec = float64(writes) / elapsed
+
+			if reads > 0 {
+				dev.ReadAwaitMs = float64(readTime) / float64(reads)
+			}
+			if writes > 0 {
+				dev.WriteAwaitMs = float64(writeTime) / float64(writes)
+			}
+			if reads+writes > 0 {
+				dev.AwaitMs = float64(readTime+writeTime) / float64(reads+writes)
+			}
+
+			// Sayaçlar milisaniye cinsinden tutulur
+			dev.QueueDepth = float64(counterDelta(old.WeightedIO, stat.WeightedIO)) / elapsedMs
+			dev.Utilization = float64(counterDelta(old.IoTime, stat.IoTime)) / elapsedMs * 100
+			if dev.Utilization > 100 {
+				dev.Utilization = 100
+			}
+		}
+
+		result = append(result, dev)
+	}
+
+	return result
+}
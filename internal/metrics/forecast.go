@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+)
+
+const (
+	// forecastMinSpan tahmin yapılabilmesi için örneklerin kapsaması gereken en kısa süre
+	forecastMinSpan = 10 * time.Minute
+
+	// forecastMaxSamples pencere başına istenen en fazla örnek sayısı;
+	// geçmiş pencere boyunca eşit adımlarla özetlenir
+	forecastMaxSamples = 720
+)
+
+// UsageSample bir dosya sisteminin belirli bir andaki kullanılan ve toplam alanı
+type UsageSample struct {
+	Time  time.Time
+	Used  float64
+	Total float64 // Boyut değişikliğini (resize) algılamak için; bilinmiyorsa 0
+}
+
+// UsageHistory dosya sistemi doluluk tahmini için kullanılan alan geçmişini sağlar.
+// Daemon bu arayüzü bellek içi geçmiş ve kalıcı depolama üzerinden uygular; böylece
+// tahmin collector yeniden oluşturulduğunda veya daemon yeniden başladığında sıfırlanmaz.
+type UsageHistory interface {
+	// Usage mountpoint'in [from, to] aralığındaki örneklerini step adımlarıyla, zaman sırasıyla döndürür
+	Usage(mountpoint string, from, to time.Time, step time.Duration) ([]UsageSample, error)
+}
+
+// FilesystemPath dosya sistemi alanının geçmiş ve alarm kurallarında kullanılan yolunu döndürür
+func FilesystemPath(mountpoint, field string) string {
+	return joinPath(joinPath("disk.filesystems", mountpoint), field)
+}
+
+// forecast bir dosya sistemi için son hesaplanan tahmin
+type forecast struct {
+	computed time.Time
+	total    uint64
+	slope    float64 // bytes/saniye
+	ok       bool
+}
+
+// diskForecaster dosya sistemlerinin kullanılan alan geçmişini UsageHistory'den
+// sorgular ve doğrusal regresyonla büyüme hızını ve dolma süresini tahmin eder.
+// Geçmiş sorgusu dosya sistemi başına en fazla step'te bir yapılır.
+type diskForecaster struct {
+	history UsageHistory
+	window  time.Duration
+	step    time.Duration
+	cache   map[string]forecast
+}
+
+// newDiskForecaster verilen pencere ve toplama aralığıyla yeni tahminci oluşturur
+func newDiskForecaster(history UsageHistory, window, interval time.Duration) *diskForecaster {
+	return &diskForecaster{
+		history: history,
+		window:  window,
+		step:    max(window/forecastMaxSamples, interval),
+		cache:   make(map[string]forecast),
+	}
+}
+
+// update her dosya sistemi için GrowthBytesPerHour ve TimeToFull alanlarını doldurur
+func (f *diskForecaster) update(now time.Time, filesystems []FilesystemMetrics) {
+	seen := make(map[string]bool, len(filesystems))
+
+	for i := range filesystems {
+		fs := &filesystems[i]
+		seen[fs.Mountpoint] = true
+
+		// Boyutu değişen dosya sisteminin tahmini hemen yeniden hesaplanır
+		fc, ok := f.cache[fs.Mountpoint]
+		if !ok || fc.total != fs.Total || now.Sub(fc.computed) >= f.step {
+			fc = f.compute(now, fs)
+			f.cache[fs.Mountpoint] = fc
+		}
+		if !fc.ok {
+			continue
+		}
+
+		growth := fc.slope * 3600
+		fs.GrowthBytesPerHour = &growth
+
+		if fc.slope > 0 {
+			ttf := float64(fs.Free) / fc.slope
+			fs.TimeToFull = &ttf
+		}
+	}
+
+	// Kaldırılan dosya sistemlerinin tahminini unut
+	for mountpoint := range f.cache {
+		if !seen[mountpoint] {
+			delete(f.cache, mountpoint)
+		}
+	}
+}
+
+// compute pencere içindeki geçmişe güncel ölçümü ekleyerek eğimi hesaplar
+func (f *diskForecaster) compute(now time.Time, fs *FilesystemMetrics) forecast {
+	fc := forecast{computed: now, total: fs.Total}
+
+	samples, err := f.history.Usage(fs.Mountpoint, now.Add(-f.window), now, f.step)
+	if err != nil {
+		logger.GetLogger().Debugf("Disk tahmini için geçmiş sorgulanamadı (%s): %v", fs.Mountpoint, err)
+		return fc
+	}
+	samples = append(samples, UsageSample{Time: now, Used: float64(fs.Used), Total: float64(fs.Total)})
+	samples = sinceResize(samples, float64(fs.Total))
+
+	if len(samples) < 3 || samples[len(samples)-1].Time.Sub(samples[0].Time) < forecastMinSpan {
+		return fc
+	}
+	fc.slope, fc.ok = usageSlope(samples)
+	return fc
+}
+
+// sinceResize son boyut değişikliğinden sonraki örnekleri döndürür; farklı
+// boyuttaki örnekler artık karşılaştırılamaz
+func sinceResize(samples []UsageSample, total float64) []UsageSample {
+	start := len(samples)
+	for start > 0 {
+		t := samples[start-1].Total
+		if t != 0 && t != total {
+			break
+		}
+		start--
+	}
+	return samples[start:]
+}
+
+// usageSlope örneklere en küçük kareler yöntemiyle doğru uydurur ve
+// eğimi (bytes/saniye) döndürür
+func usageSlope(samples []UsageSample) (float64, bool) {
+	n := float64(len(samples))
+	origin := samples[0].Time
+
+	var sumX, sumY float64
+	for _, s := range samples {
+		sumX += s.Time.Sub(origin).Seconds()
+		sumY += s.Used
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for _, s := range samples {
+		dx := s.Time.Sub(origin).Seconds() - meanX
+		sxx += dx * dx
+		sxy += dx * (s.Used - meanY)
+	}
+	if sxx == 0 {
+		return 0, false
+	}
+	return sxy / sxx, true
+}
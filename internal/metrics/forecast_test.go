@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeUsageHistory testler için bellek içi kullanılan alan geçmişi
+type fakeUsageHistory struct {
+	samples map[string][]UsageSample
+	queries int
+}
+
+func (h *fakeUsageHistory) Usage(mountpoint string, from, to time.Time, step time.Duration) ([]UsageSample, error) {
+	h.queries++
+	var result []UsageSample
+	for _, s := range h.samples[mountpoint] {
+		if !s.Time.Before(from) && !s.Time.After(to) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (h *fakeUsageHistory) add(now time.Time, filesystems []FilesystemMetrics) {
+	for _, fs := range filesystems {
+		h.samples[fs.Mountpoint] = append(h.samples[fs.Mountpoint], UsageSample{Time: now, Used: float64(fs.Used), Total: float64(fs.Total)})
+	}
+}
+
+func TestDiskForecast(t *testing.T) {
+	history := &fakeUsageHistory{samples: make(map[string][]UsageSample)}
+	base := time.Now()
+	const gb = 1 << 30
+
+	// /data saatte 2 GB büyür, / sabit kalır
+	snapshot := func(i int) []FilesystemMetrics {
+		used := uint64(50*gb + i*2*gb/60)
+		return []FilesystemMetrics{
+			{Mountpoint: "/data", Total: 100 * gb, Used: used, Free: 100*gb - used},
+			{Mountpoint: "/", Total: 100 * gb, Used: 20 * gb, Free: 80 * gb},
+		}
+	}
+
+	// Önceki collector'ın (veya daemon'un) kaydettiği geçmiş
+	for i := 0; i < 30; i++ {
+		history.add(base.Add(time.Duration(i)*time.Minute), snapshot(i))
+	}
+
+	// Yeni oluşturulan tahminci geçmişi sorgulayarak hemen tahmin yapabilmeli
+	f := newDiskForecaster(history, time.Hour, time.Minute)
+	now := base.Add(30 * time.Minute)
+	filesystems := snapshot(30)
+	f.update(now, filesystems)
+	data, root := filesystems[0], filesystems[1]
+
+	if data.GrowthBytesPerHour == nil || math.Abs(*data.GrowthBytesPerHour-2*gb) > 0.01*gb {
+		t.Fatalf("Expected growth of ~2GB/h, got %v", data.GrowthBytesPerHour)
+	}
+	if data.TimeToFull == nil {
+		t.Fatal("Expected time to full for growing filesystem")
+	}
+	expected := float64(data.Free) / (2 * gb) * 3600
+	if math.Abs(*data.TimeToFull-expected) > 0.01*expected {
+		t.Errorf("Expected time to full ~%.0fs, got %.0fs", expected, *data.TimeToFull)
+	}
+
+	if root.GrowthBytesPerHour == nil || *root.GrowthBytesPerHour != 0 {
+		t.Errorf("Expected zero growth for stable filesystem, got %v", root.GrowthBytesPerHour)
+	}
+	if root.TimeToFull != nil {
+		t.Errorf("Expected no time to full for stable filesystem, got %v", *root.TimeToFull)
+	}
+
+	// Alan yolları alarm kurallarında kullanılabilmeli
+	m := &SystemMetrics{Disk: &DiskMetrics{Filesystems: []FilesystemMetrics{data}}}
+	if v, ok := m.Value(FilesystemPath("/data", "time_to_full_seconds")); !ok || v != *data.TimeToFull {
+		t.Errorf("Expected time_to_full_seconds path, got %v %v", v, ok)
+	}
+
+	// Step dolmadan geçmiş yeniden sorgulanmaz
+	queries := history.queries
+	f.update(now.Add(time.Second), snapshot(30))
+	if history.queries != queries {
+		t.Errorf("Expected cached forecast within step, got %d new queries", history.queries-queries)
+	}
+
+	// Boyutu değişen dosya sisteminin eski geçmişi kullanılmamalı
+	resized := []FilesystemMetrics{{Mountpoint: "/data", Total: 200 * gb, Used: 110 * gb, Free: 90 * gb}}
+	f.update(now.Add(time.Minute), resized)
+	if resized[0].GrowthBytesPerHour != nil {
+		t.Error("Expected forecast to reset after resize")
+	}
+	if _, ok := f.cache["/"]; ok {
+		t.Error("Expected removed filesystem forecast to be dropped")
+	}
+}
+
+func TestDiskForecastMinSpan(t *testing.T) {
+	history := &fakeUsageHistory{samples: make(map[string][]UsageSample)}
+	base := time.Now()
+
+	// 10 dakikadan kısa geçmişle tahmin yapılmamalı
+	for i := 0; i < 5; i++ {
+		history.add(base.Add(time.Duration(i)*time.Minute), []FilesystemMetrics{{Mountpoint: "/", Total: 100, Used: uint64(10 + i)}})
+	}
+	f := newDiskForecaster(history, time.Hour, time.Minute)
+	filesystems := []FilesystemMetrics{{Mountpoint: "/", Total: 100, Used: 15, Free: 85}}
+	f.update(base.Add(5*time.Minute), filesystems)
+	if filesystems[0].GrowthBytesPerHour != nil {
+		t.Errorf("Expected no forecast before %s of history", forecastMinSpan)
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+)
+
+// HostMetrics host seviyesindeki yük ve çalışma süresi bilgilerini içerir
+type HostMetrics struct {
+	Load1        float64   `json:"load1"`         // 1 dakikalık load average
+	Load5        float64   `json:"load5"`         // 5 dakikalık load average
+	Load15       float64   `json:"load15"`        // 15 dakikalık load average
+	ProcsRunning int       `json:"procs_running"` // Çalışır durumdaki process sayısı
+	ProcsBlocked int       `json:"procs_blocked"` // I/O beklerken bloklanmış process sayısı
+	Uptime       uint64    `json:"uptime"`        // Açık kalma süresi (saniye)
+	BootTime     time.Time `json:"boot_time"`     // Sistemin açıldığı zaman
+}
+
+// hostSubsystem load average ve uptime bilgilerini toplar; önceki örnek tutmaz
+type hostSubsystem struct{}
+
+func (s *hostSubsystem) start(cfg config.MetricsConfig) {}
+
+func (s *hostSubsystem) collectInto(m *SystemMetrics) error {
+	hostMetrics, err := s.collect()
+	m.Host = hostMetrics
+	return err
+}
+
+// collect load average, process durumları ve uptime bilgilerini toplar
+func (s *hostSubsystem) collect() (*HostMetrics, error) {
+	avg, err := load.Avg()
+	if err != nil {
+		return nil, err
+	}
+
+	bootTime, err := host.BootTime()
+	if err != nil {
+		return nil, err
+	}
+
+	// Process durumları her platformda desteklenmez, yoksa sıfır kalır
+	var misc *load.MiscStat
+	if m, err := load.Misc(); err == nil {
+		misc = m
+	}
+
+	return newHostMetrics(avg, bootTime, misc, time.Now()), nil
+}
+
+// newHostMetrics gopsutil değerlerinden host metriklerini oluşturur. misc nil
+// olabilir; saat geri alınmışsa (now < boot) uptime sıfır kalır.
+func newHostMetrics(avg *load.AvgStat, bootTime uint64, misc *load.MiscStat, now time.Time) *HostMetrics {
+	metrics := &HostMetrics{
+		Load1:    avg.Load1,
+		Load5:    avg.Load5,
+		Load15:   avg.Load15,
+		BootTime: time.Unix(int64(bootTime), 0),
+	}
+
+	// Uptime boot zamanından türetilir (host.Uptime ayrıca aynı dosyayı okur)
+	if sec := uint64(now.Unix()); sec > bootTime {
+		metrics.Uptime = sec - bootTime
+	}
+
+	if misc != nil {
+		metrics.ProcsRunning = misc.ProcsRunning
+		metrics.ProcsBlocked = misc.ProcsBlocked
+	}
+
+	return metrics
+}
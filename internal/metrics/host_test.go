@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/load"
+)
+
+func TestNewHostMetrics(t *testing.T) {
+	boot := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	avg := &load.AvgStat{Load1: 0.5, Load5: 1.25, Load15: 2}
+
+	testCases := []struct {
+		name        string
+		now         time.Time
+		misc        *load.MiscStat
+		wantUptime  uint64
+		wantRunning int
+		wantBlocked int
+	}{
+		{"running host", boot.Add(90 * time.Minute), &load.MiscStat{ProcsRunning: 3, ProcsBlocked: 1}, 5400, 3, 1},
+		{"process states unavailable", boot.Add(time.Hour), nil, 3600, 0, 0},
+		{"clock behind boot time", boot.Add(-time.Minute), nil, 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newHostMetrics(avg, uint64(boot.Unix()), tc.misc, tc.now)
+
+			if m.Load1 != 0.5 || m.Load5 != 1.25 || m.Load15 != 2 {
+				t.Errorf("Unexpected load averages: %+v", m)
+			}
+			if !m.BootTime.Equal(boot) {
+				t.Errorf("Expected boot time %s, got %s", boot, m.BootTime)
+			}
+			if m.Uptime != tc.wantUptime {
+				t.Errorf("Expected uptime %d, got %d", tc.wantUptime, m.Uptime)
+			}
+			if m.ProcsRunning != tc.wantRunning || m.ProcsBlocked != tc.wantBlocked {
+				t.Errorf("Expected procs running=%d blocked=%d, got running=%d blocked=%d",
+					tc.wantRunning, tc.wantBlocked, m.ProcsRunning, m.ProcsBlocked)
+			}
+		})
+	}
+}
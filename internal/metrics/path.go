@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Alan yolları JSON isimlerinin noktayla birleştirilmesinden oluşur, örn:
+//
+//	cpu.usage
+//	memory.swap.used
+//	network.interfaces.eth0.recv_bytes_per_sec
+//	disk.filesystems./data.usage
+//	watchlist.nginx.up
+//	sources.gpu.temperature
+//
+// Liste elemanları anahtar alanları (name, mountpoint) ile adreslenir.
+// Process listeleri her ölçümde değiştiğinden yol olarak sunulmaz.
+
+// sliceKeyFields liste elemanlarını adreslemek için kullanılan alanlar (öncelik sırasıyla)
+var sliceKeyFields = []string{"name", "mountpoint"}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	processesType = reflect.TypeOf(ProcessesMetrics{})
+	samplesType   = reflect.TypeOf(map[string][]Sample{})
+)
+
+// Value verilen alan yolundaki sayısal değeri döndürür.
+// Bool alanlar 1/0 olarak döner; yol bulunamazsa veya sayısal değilse false döner.
+func (m *SystemMetrics) Value(path string) (float64, bool) {
+	if m == nil || path == "" {
+		return 0, false
+	}
+
+	var (
+		result float64
+		found  bool
+	)
+	walkValue(reflect.ValueOf(m), "", func(p string, v float64) bool {
+		if p == path {
+			result, found = v, true
+			return false
+		}
+		return true
+	}, path)
+
+	return result, found
+}
+
+// Fields snapshot'taki tüm sayısal alan yollarını sıralı olarak döndürür
+func (m *SystemMetrics) Fields() []string {
+	var fields []string
+	if m == nil {
+		return fields
+	}
+
+	walkValue(reflect.ValueOf(m), "", func(p string, v float64) bool {
+		fields = append(fields, p)
+		return true
+	}, "")
+
+	sort.Strings(fields)
+	return fields
+}
+
+// Flatten snapshot'taki tüm sayısal alanları yol → değer map'i olarak döndürür
+func (m *SystemMetrics) Flatten() map[string]float64 {
+	values := make(map[string]float64)
+	if m == nil {
+		return values
+	}
+
+	walkValue(reflect.ValueOf(m), "", func(p string, v float64) bool {
+		values[p] = v
+		return true
+	}, "")
+
+	return values
+}
+
+// walkValue değeri gezerek her sayısal alan için visit fonksiyonunu çağırır.
+// target boş değilse sadece bu yolun öneki olan dallara inilir.
+// visit false dönerse gezinme durur; fonksiyonun dönüş değeri devam edilip edilmeyeceğidir.
+func walkValue(v reflect.Value, prefix string, visit func(string, float64) bool, target string) bool {
+	if target != "" && prefix != "" && !strings.HasPrefix(target, prefix) {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return true
+		}
+		return walkValue(v.Elem(), prefix, visit, target)
+
+	case reflect.Struct:
+		if v.Type() == timeType || v.Type() == processesType {
+			return true
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := jsonName(field)
+			if name == "" {
+				// Gömülü alanlar üst seviyede sayılır
+				if field.Anonymous {
+					if !walkValue(v.Field(i), prefix, visit, target) {
+						return false
+					}
+				}
+				continue
+			}
+			if !walkValue(v.Field(i), joinPath(prefix, name), visit, target) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			key := sliceElemKey(elem)
+			if key == "" {
+				key = strconv.Itoa(i)
+			}
+			if !walkValue(elem, joinPath(prefix, key), visit, target) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if v.Type() == samplesType {
+			return walkSamples(v, prefix, visit, target)
+		}
+		// Diğer map'ler (ör. Errors) sayısal alan içermez
+		return true
+
+	case reflect.Float32, reflect.Float64:
+		return visit(prefix, v.Float())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return visit(prefix, float64(v.Int()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return visit(prefix, float64(v.Uint()))
+
+	case reflect.Bool:
+		if v.Bool() {
+			return visit(prefix, 1)
+		}
+		return visit(prefix, 0)
+	}
+
+	return true
+}
+
+// walkSamples eklenti kaynaklarının örneklerini sources.<id>.<isim> yolunda sunar
+func walkSamples(v reflect.Value, prefix string, visit func(string, float64) bool, target string) bool {
+	sources := v.Interface().(map[string][]Sample)
+
+	ids := make([]string, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		seen := make(map[string]bool)
+		for _, sample := range sources[id] {
+			// Aynı isimli birden fazla örnek varsa (farklı label'lar) ilki kullanılır
+			if seen[sample.Name] {
+				continue
+			}
+			seen[sample.Name] = true
+			if !visit(joinPath(joinPath(prefix, id), sample.Name), sample.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sliceElemKey liste elemanının anahtar alanının değerini döndürür
+func sliceElemKey(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	t := v.Type()
+	for _, key := range sliceKeyFields {
+		for i := 0; i < t.NumField(); i++ {
+			if jsonName(t.Field(i)) == key && v.Field(i).Kind() == reflect.String {
+				return v.Field(i).String()
+			}
+		}
+	}
+	return ""
+}
+
+// jsonName struct alanının JSON ismini döndürür; gömülü veya gizli alanlar için boş döner
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" && !field.Anonymous {
+		name = field.Name
+	}
+	return name
+}
+
+// joinPath yol parçalarını nokta ile birleştirir
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
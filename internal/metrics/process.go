@@ -0,0 +1,290 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Process sıralama anahtarları
+const (
+	ProcessSortCPU     = "cpu"
+	ProcessSortMemory  = "memory"
+	ProcessSortThreads = "threads"
+)
+
+// redactedValue maskelenen cmdline değerlerinin yerine yazılır
+const redactedValue = "***"
+
+// secretArgKeywords bu kelimeleri içeren argümanların değerleri maskelenir
+var secretArgKeywords = []string{"password", "passwd", "secret", "token", "key", "auth", "credential"}
+
+// ProcessesMetrics process listesinin özetini içerir
+type ProcessesMetrics struct {
+	Total int                         `json:"total"` // Örneklenen toplam process sayısı
+	Top   map[string][]ProcessMetrics `json:"top"`   // Sıralama anahtarına göre ilk N process
+}
+
+// ProcessMetrics tek bir process'in kaynak kullanımını içerir
+type ProcessMetrics struct {
+	PID        int32     `json:"pid"`
+	Name       string    `json:"name"`
+	Cmdline    string    `json:"cmdline"`
+	Username   string    `json:"username"`
+	CreateTime time.Time `json:"create_time"`
+	CPUPercent float64   `json:"cpu_percent"` // Önceki ölçümden bu yana (çok çekirdekte 100'ü aşabilir)
+	RSS        uint64    `json:"rss"`         // Resident set size (bytes)
+	MemPercent float64   `json:"mem_percent"` // Toplam belleğe oranı
+	ReadBytes  uint64    `json:"read_bytes"`  // Okunan bytes (kümülatif)
+	WriteBytes uint64    `json:"write_bytes"` // Yazılan bytes (kümülatif)
+	OpenFDs    int32     `json:"open_fds"`
+	Threads    int32     `json:"threads"`
+}
+
+// processSample CPU yüzdesini delta ile hesaplamak için saklanan önceki örnek
+type processSample struct {
+	cpuTime    float64 // user + system (saniye)
+	createTime int64   // PID yeniden kullanımını ayırt etmek için
+}
+
+// processCandidate ilk geçişte toplanan ucuz bilgiler
+type processCandidate struct {
+	proc       *process.Process
+	metrics    ProcessMetrics
+	createTime int64
+}
+
+// processSubsystem process listesini örnekler; CPU yüzdesi için önceki örnekleri tutar
+type processSubsystem struct {
+	config config.MetricsConfig
+
+	// Process bazında CPU yüzdesi için önceki örnekler
+	prevSamples map[int32]processSample
+	prevTime    time.Time
+}
+
+// start ilk process örneklerini alır
+func (s *processSubsystem) start(cfg config.MetricsConfig) {
+	s.config = cfg
+	s.collect()
+}
+
+func (s *processSubsystem) collectInto(m *SystemMetrics) error {
+	procMetrics, err := s.collect()
+	m.Processes = procMetrics
+	return err
+}
+
+// collect tüm process'leri örnekler ve her sıralama anahtarı için ilk N'i döndürür.
+// Pahalı alanlar (cmdline, I/O, FD) sadece listeye giren process'ler için okunur.
+func (s *processSubsystem) collect() (*ProcessesMetrics, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	var totalMem uint64
+	if vm, err := mem.VirtualMemory(); err == nil {
+		totalMem = vm.Total
+	}
+
+	now := time.Now()
+	var elapsed float64
+	if !s.prevTime.IsZero() {
+		elapsed = now.Sub(s.prevTime).Seconds()
+	}
+
+	sortKeys := s.processSortKeys()
+	needThreads := containsString(sortKeys, ProcessSortThreads)
+
+	samples := make(map[int32]processSample, len(procs))
+	candidates := make([]processCandidate, 0, len(procs))
+
+	for _, p := range procs {
+		// Örnekleme sırasında sonlanan process'ler sessizce atlanır
+		times, err := p.Times()
+		if err != nil {
+			continue
+		}
+		createTime, _ := p.CreateTime()
+		cpuTime := times.User + times.System
+
+		cand := processCandidate{
+			proc:       p,
+			createTime: createTime,
+			metrics:    ProcessMetrics{PID: p.Pid},
+		}
+
+		prev, ok := s.prevSamples[p.Pid]
+		if ok && prev.createTime == createTime && elapsed > 0 && cpuTime >= prev.cpuTime {
+			cand.metrics.CPUPercent = (cpuTime - prev.cpuTime) / elapsed * 100
+		}
+		samples[p.Pid] = processSample{cpuTime: cpuTime, createTime: createTime}
+
+		if memInfo, err := p.MemoryInfo(); err == nil {
+			cand.metrics.RSS = memInfo.RSS
+			if totalMem > 0 {
+				cand.metrics.MemPercent = float64(memInfo.RSS) / float64(totalMem) * 100
+			}
+		}
+
+		if needThreads {
+			cand.metrics.Threads, _ = p.NumThreads()
+		}
+
+		candidates = append(candidates, cand)
+	}
+
+	s.prevSamples = samples
+	s.prevTime = now
+
+	metrics := &ProcessesMetrics{
+		Total: len(candidates),
+		Top:   make(map[string][]ProcessMetrics, len(sortKeys)),
+	}
+
+	// Aynı process birden fazla listede yer alabilir, detaylar bir kez okunur
+	enriched := make(map[int32]ProcessMetrics)
+	for _, key := range sortKeys {
+		top := topProcesses(candidates, key, s.config.ProcessTopN)
+		list := make([]ProcessMetrics, 0, len(top))
+		for _, cand := range top {
+			pm, ok := enriched[cand.metrics.PID]
+			if !ok {
+				pm = s.enrichProcess(cand)
+				enriched[pm.PID] = pm
+			}
+			list = append(list, pm)
+		}
+		metrics.Top[key] = list
+	}
+
+	return metrics, nil
+}
+
+// enrichProcess listeye giren process için pahalı alanları okur
+func (s *processSubsystem) enrichProcess(cand processCandidate) ProcessMetrics {
+	p := cand.proc
+	pm := cand.metrics
+
+	pm.Name, _ = p.Name()
+	pm.Username, _ = p.Username()
+	if cand.createTime > 0 {
+		pm.CreateTime = time.UnixMilli(cand.createTime)
+	}
+	if pm.Threads == 0 {
+		pm.Threads, _ = p.NumThreads()
+	}
+	pm.OpenFDs, _ = p.NumFDs()
+
+	if io, err := p.IOCounters(); err == nil {
+		pm.ReadBytes = io.ReadBytes
+		pm.WriteBytes = io.WriteBytes
+	}
+
+	if args, err := p.CmdlineSlice(); err == nil {
+		if s.config.ProcessRedactCmdline {
+			args = redactCmdline(args)
+		}
+		pm.Cmdline = strings.Join(args, " ")
+	}
+
+	return pm
+}
+
+// processSortKeys geçerli sıralama anahtarlarını döndürür, yoksa cpu ve memory kullanılır
+func (s *processSubsystem) processSortKeys() []string {
+	keys := make([]string, 0, len(s.config.ProcessSortBy))
+	for _, key := range s.config.ProcessSortBy {
+		switch key {
+		case ProcessSortCPU, ProcessSortMemory, ProcessSortThreads:
+			if !containsString(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		keys = []string{ProcessSortCPU, ProcessSortMemory}
+	}
+	return keys
+}
+
+// topProcesses adayları verilen anahtara göre azalan sırada sıralar ve ilk n tanesini döndürür
+func topProcesses(candidates []processCandidate, key string, n int) []processCandidate {
+	sorted := make([]processCandidate, len(candidates))
+	copy(sorted, candidates)
+
+	value := func(pm ProcessMetrics) float64 {
+		switch key {
+		case ProcessSortMemory:
+			return float64(pm.RSS)
+		case ProcessSortThreads:
+			return float64(pm.Threads)
+		default:
+			return pm.CPUPercent
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		vi, vj := value(sorted[i].metrics), value(sorted[j].metrics)
+		if vi != vj {
+			return vi > vj
+		}
+		return sorted[i].metrics.PID < sorted[j].metrics.PID
+	})
+
+	if n > 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// redactCmdline gizli bilgi içerebilecek argümanların değerlerini maskeler.
+// "--password=x" biçimi ve "--password x" biçimi desteklenir.
+func redactCmdline(args []string) []string {
+	result := make([]string, len(args))
+	copy(result, args)
+
+	for i := 1; i < len(result); i++ {
+		arg := result[i]
+		if !strings.HasPrefix(arg, "-") || !isSecretArg(arg) {
+			continue
+		}
+		if idx := strings.Index(arg, "="); idx >= 0 {
+			result[i] = arg[:idx+1] + redactedValue
+		} else if i+1 < len(result) && !strings.HasPrefix(result[i+1], "-") {
+			result[i+1] = redactedValue
+			i++
+		}
+	}
+
+	return result
+}
+
+// isSecretArg argüman isminin gizli bilgi anahtar kelimelerinden birini içerip içermediğini kontrol eder
+func isSecretArg(arg string) bool {
+	name := strings.ToLower(arg)
+	if idx := strings.Index(name, "="); idx >= 0 {
+		name = name[:idx]
+	}
+	for _, keyword := range secretArgKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString slice'ın verilen değeri içerip içermediğini kontrol eder
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+)
+
+// SampleType metrik örneğinin türü
+type SampleType string
+
+// Desteklenen örnek türleri
+const (
+	SampleGauge   SampleType = "gauge"   // Anlık değer (sıcaklık, kuyruk uzunluğu)
+	SampleCounter SampleType = "counter" // Sadece artan kümülatif değer
+)
+
+// Sample bir metrik kaynağının ürettiği tek bir değer
+type Sample struct {
+	Name   string            `json:"name"`
+	Value  float64           `json:"value"`
+	Type   SampleType        `json:"type"`
+	Unit   string            `json:"unit,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// MetricSource collector'a eklenebilen metrik kaynağı.
+// Kaynaklar Register ile kaydedilir ve config'deki metrics.sources listesi ile etkinleştirilir.
+type MetricSource interface {
+	// Name kaynağın kayıt ismini döndürür
+	Name() string
+	// Init config'deki seçeneklerle kaynağı hazırlar; collector başlatılırken bir kez çağrılır
+	Init(options map[string]interface{}) error
+	// Collect her toplama döngüsünde çağrılır
+	Collect(ctx context.Context) ([]Sample, error)
+	// Close collector durdurulurken kaynakları serbest bırakır
+	Close() error
+}
+
+// OptionsSource seçenek alan kaynakların uygulayabileceği arayüz. NewOptions
+// Init'in seçenekleri çözdüğü struct'a yeni bir pointer döndürür; config validate
+// bilinmeyen seçenekleri kaynağı başlatmadan bununla bulur.
+type OptionsSource interface {
+	NewOptions() interface{}
+}
+
+// SourceFactory yeni bir MetricSource instance'ı oluşturur
+type SourceFactory func() MetricSource
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]SourceFactory)
+)
+
+// Register bir metrik kaynağını verilen isimle kaydeder.
+// Genellikle kaynağın init() fonksiyonundan çağrılır; aynı isim iki kez kaydedilirse panic olur.
+func Register(name string, factory SourceFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("metrics: Register factory nil: " + name)
+	}
+	if _, exists := registry[name]; exists {
+		panic("metrics: Register aynı isimle iki kez çağrıldı: " + name)
+	}
+	registry[name] = factory
+
+	var options config.OptionsFunc
+	if src, ok := factory().(OptionsSource); ok {
+		options = src.NewOptions
+	}
+	config.RegisterSourceType(name, options)
+}
+
+// RegisteredSources kayıtlı kaynak isimlerini sıralı olarak döndürür
+func RegisteredSources() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newSource kayıtlı bir kaynaktan yeni instance oluşturur
+func newSource(name string) (MetricSource, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("bilinmeyen metrik kaynağı: %s (kayıtlı kaynaklar: %v)", name, RegisteredSources())
+	}
+	return factory(), nil
+}
+
+// DecodeOptions config'deki seçenek map'ini verilen struct'a dönüştürür.
+// Struct'ta olmayan seçenekler hata döndürür.
+func DecodeOptions(options map[string]interface{}, target interface{}) error {
+	if err := config.DecodeOptions(options, target); err != nil {
+		return fmt.Errorf("kaynak seçenekleri geçersiz: %w", err)
+	}
+	return nil
+}
+
+// activeSource başlatılmış bir kaynak ve config'deki ayarları
+type activeSource struct {
+	id      string // Config'deki isim (aynı tipten birden fazla kaynak olabilir)
+	source  MetricSource
+	timeout time.Duration
+
+	// enable_* ile açılan yerleşik alt sistem; nil ise örnekler Sources map'ine yazılır
+	subsystem subsystem
+}
+
+// sourceID config'deki kaynak tanımının benzersiz ismini döndürür
+func sourceID(cfg config.SourceConfig) string {
+	if cfg.ID != "" {
+		return cfg.ID
+	}
+	return cfg.Type
+}
+
+// startSources enable_* ayarlarıyla açılan yerleşik alt sistemleri ve config'deki
+// kaynakları oluşturur ve başlatır. Herhangi bir kaynak başlatılamazsa önceden
+// başlatılanlar kapatılır.
+func (c *Collector) startSources() error {
+	for _, b := range builtins {
+		if !b.enabled(c.config) {
+			continue
+		}
+		if err := c.startSource(config.SourceConfig{Type: b.name}, true); err != nil {
+			return err
+		}
+	}
+
+	for _, sc := range c.config.Sources {
+		if err := c.startSource(sc, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startSource tek bir kaynağı oluşturup başlatır. typed true ise yerleşik alt
+// sistem snapshot'ın kendi alanına, değilse Sources map'ine yazılır.
+func (c *Collector) startSource(sc config.SourceConfig, typed bool) error {
+	src, err := newSource(sc.Type)
+	if err != nil {
+		c.closeSources()
+		return err
+	}
+
+	// Yerleşik kaynaklar filtre ve pencere ayarlarını metrics bölümünden alır
+	builtin, isBuiltin := src.(*builtinSource)
+	if isBuiltin {
+		builtin.config = c.config
+		builtin.history = c.history
+	}
+
+	if err := src.Init(sc.Options); err != nil {
+		c.closeSources()
+		return fmt.Errorf("metrik kaynağı başlatılamadı (%s): %w", sourceID(sc), err)
+	}
+
+	timeout := time.Duration(sc.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = time.Duration(c.config.Interval) * time.Second
+	}
+
+	as := activeSource{id: sourceID(sc), source: src, timeout: timeout}
+	if typed && isBuiltin {
+		as.subsystem = builtin.sub
+	}
+	c.sources = append(c.sources, as)
+
+	if !typed {
+		logger.GetLogger().Infof("Metrik kaynağı başlatıldı: %s (%s)", sourceID(sc), sc.Type)
+	}
+	return nil
+}
+
+// closeSources başlatılmış tüm kaynakları kapatır
+func (c *Collector) closeSources() {
+	log := logger.GetLogger()
+
+	for _, as := range c.sources {
+		if err := as.source.Close(); err != nil {
+			log.Errorf("Metrik kaynağı kapatılırken hata (%s): %v", as.id, err)
+		}
+	}
+	c.sources = nil
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register("file", func() MetricSource { return &fileSource{} })
+}
+
+// fileSource tek bir dosyadan sayısal değer okuyan kaynak.
+// /sys altındaki sıcaklık, fan hızı gibi host'a özel göstergeler için kullanılır.
+type fileSource struct {
+	opts fileSourceOptions
+}
+
+// fileSourceOptions "file" kaynağının config seçenekleri
+type fileSourceOptions struct {
+	Path   string            `json:"path"`            // Okunacak dosya
+	Metric string            `json:"metric"`          // Üretilecek metrik ismi
+	Scale  float64           `json:"scale,omitempty"` // Okunan değer bu katsayı ile çarpılır (varsayılan 1)
+	Unit   string            `json:"unit,omitempty"`
+	Type   SampleType        `json:"type,omitempty"` // gauge veya counter (varsayılan gauge)
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Name kaynağın kayıt ismini döndürür
+func (s *fileSource) Name() string {
+	return "file"
+}
+
+// NewOptions config doğrulaması için boş seçenek struct'ı döndürür
+func (s *fileSource) NewOptions() interface{} {
+	return &fileSourceOptions{}
+}
+
+// Init seçenekleri doğrular
+func (s *fileSource) Init(options map[string]interface{}) error {
+	if err := DecodeOptions(options, &s.opts); err != nil {
+		return err
+	}
+	if s.opts.Path == "" {
+		return fmt.Errorf("path belirtilmeli")
+	}
+	if s.opts.Metric == "" {
+		return fmt.Errorf("metric belirtilmeli")
+	}
+	if s.opts.Scale == 0 {
+		s.opts.Scale = 1
+	}
+	switch s.opts.Type {
+	case "":
+		s.opts.Type = SampleGauge
+	case SampleGauge, SampleCounter:
+	default:
+		return fmt.Errorf("geçersiz örnek türü: %s", s.opts.Type)
+	}
+	return nil
+}
+
+// Collect dosyayı okur ve tek bir örnek döndürür
+func (s *fileSource) Collect(ctx context.Context) ([]Sample, error) {
+	data, err := os.ReadFile(s.opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+	if err != nil {
+		return nil, fmt.Errorf("dosya içeriği sayı değil (%s): %w", s.opts.Path, err)
+	}
+
+	return []Sample{{
+		Name:   s.opts.Metric,
+		Value:  value * s.opts.Scale,
+		Type:   s.opts.Type,
+		Unit:   s.opts.Unit,
+		Labels: s.opts.Labels,
+	}}, nil
+}
+
+// Close dosya kaynağı kalıcı kaynak tutmaz
+func (s *fileSource) Close() error {
+	return nil
+}
@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+)
+
+// stubSource testler için sabit değer üreten kaynak
+type stubSource struct {
+	closed bool
+	fail   bool
+}
+
+func (s *stubSource) Name() string { return "stub" }
+
+func (s *stubSource) Init(options map[string]interface{}) error {
+	var opts struct {
+		Fail bool `json:"fail"`
+	}
+	if err := DecodeOptions(options, &opts); err != nil {
+		return err
+	}
+	s.fail = opts.Fail
+	return nil
+}
+
+func (s *stubSource) Collect(ctx context.Context) ([]Sample, error) {
+	if s.fail {
+		return nil, errors.New("stub hata")
+	}
+	return []Sample{{Name: "stub_value", Value: 42, Type: SampleGauge}}, nil
+}
+
+func (s *stubSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+func init() {
+	Register("stub", func() MetricSource { return &stubSource{} })
+}
+
+func TestRegisteredSources(t *testing.T) {
+	names := RegisteredSources()
+	if !containsString(names, "file") || !containsString(names, "stub") {
+		t.Errorf("Expected built-in and test sources to be registered, got %v", names)
+	}
+	for _, b := range builtins {
+		if !containsString(names, b.name) {
+			t.Errorf("Expected built-in subsystem %q to be registered", b.name)
+		}
+	}
+}
+
+func TestCollectorBuiltinSources(t *testing.T) {
+	cfg := config.Default().Metrics
+	cfg.EnableHost, cfg.EnableCPU, cfg.EnableDisk, cfg.EnableNet = false, false, false, false
+	cfg.EnableDiskIO, cfg.EnableProcesses = false, false
+	cfg.Sources = []config.SourceConfig{{Type: SubsystemMemory, ID: "mem"}}
+	
+	collector := NewCollectorWithConfig(cfg)
+	if err := collector.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	defer collector.Stop()
+	
+	// enable_memory ile açılan alt sistem ve metrics.sources'taki kopyası ayrı kaynaklardır
+	if len(collector.sources) != 2 || collector.sources[0].subsystem == nil || collector.sources[1].subsystem != nil {
+		t.Fatalf("Expected typed memory subsystem and plug-in copy, got %+v", collector.sources)
+	}
+	
+	metrics, err := collector.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll() failed: %v", err)
+	}
+	if metrics.Memory == nil || metrics.CPU != nil {
+		t.Errorf("Expected only memory subsystem to be collected, got %+v", metrics)
+	}
+	
+	found := false
+	for _, s := range metrics.Sources["mem"] {
+		if s.Name == "memory.total" && s.Value > 0 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected memory.total sample from built-in source, got %+v", metrics.Sources["mem"])
+	}
+	
+	// Yerleşik kaynaklar seçenekleri metrics bölümünden alır
+	bad := config.Default().Metrics
+	bad.Sources = []config.SourceConfig{{Type: SubsystemCPU, Options: map[string]interface{}{"x": 1}}}
+	if err := NewCollectorWithConfig(bad).Start(); err == nil {
+		t.Error("Expected Start() to fail for built-in source with options")
+	}
+}
+
+func TestCollectorSources(t *testing.T) {
+	cfg := config.Default().Metrics
+	cfg.EnableHost, cfg.EnableCPU, cfg.EnableMemory, cfg.EnableDisk = false, false, false, false
+	cfg.EnableNet, cfg.EnableDiskIO, cfg.EnableProcesses = false, false, false
+	cfg.Sources = []config.SourceConfig{
+		{Type: "stub"},
+		{Type: "stub", ID: "broken", Options: map[string]interface{}{"fail": true}},
+	}
+	
+	collector := NewCollectorWithConfig(cfg)
+	if err := collector.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	
+	metrics, err := collector.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll() failed: %v", err)
+	}
+	
+	if samples := metrics.Sources["stub"]; len(samples) != 1 || samples[0].Value != 42 {
+		t.Errorf("Unexpected stub samples: %+v", samples)
+	}
+	
+	// Hata veren kaynak diğerlerini etkilememeli
+	if _, ok := metrics.Errors[SourceErrorPrefix+"broken"]; !ok {
+		t.Errorf("Expected error for broken source, got %v", metrics.Errors)
+	}
+	
+	stub := collector.sources[0].source.(*stubSource)
+	collector.Stop()
+	if !stub.closed {
+		t.Error("Sources should be closed on Stop()")
+	}
+}
+
+func TestCollectorUnknownSource(t *testing.T) {
+	cfg := config.Default().Metrics
+	cfg.Sources = []config.SourceConfig{{Type: "does-not-exist"}}
+	
+	if err := NewCollectorWithConfig(cfg).Start(); err == nil {
+		t.Error("Expected Start() to fail for unknown source")
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "temp")
+	if err := os.WriteFile(path, []byte("48500\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	
+	src := &fileSource{}
+	err := src.Init(map[string]interface{}{
+		"path":   path,
+		"metric": "cpu_temperature",
+		"scale":  0.001,
+		"unit":   "celsius",
+	})
+	if err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	
+	samples, err := src.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect() failed: %v", err)
+	}
+	if len(samples) != 1 || samples[0].Value != 48.5 || samples[0].Type != SampleGauge {
+		t.Errorf("Unexpected samples: %+v", samples)
+	}
+	
+	// Eksik seçenekler reddedilmeli
+	if err := (&fileSource{}).Init(map[string]interface{}{"metric": "x"}); err == nil {
+		t.Error("Expected Init() to fail without path")
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"sync"
+)
+
+// Store en son toplanan metrik snapshot'ını thread-safe şekilde saklar.
+// Daemon döngüsü Publish ile yazar, dashboard gibi okuyucular Latest ile okur;
+// böylece toplama maliyeti okuyucu sayısından bağımsız kalır.
+type Store struct {
+	mu     sync.RWMutex
+	latest *SystemMetrics
+	subs   map[*Subscription]struct{}
+}
+
+// Subscription Store'a yayınlanan snapshot'ları alan abonelik.
+// C kanalı abonelik kapatıldığında veya abone geride kaldığında kapanır.
+type Subscription struct {
+	C <-chan *SystemMetrics
+
+	ch      chan *SystemMetrics
+	store   *Store
+	dropped bool
+}
+
+// NewStore boş bir snapshot store'u oluşturur
+func NewStore() *Store {
+	return &Store{subs: make(map[*Subscription]struct{})}
+}
+
+// Publish yeni snapshot'ı en son değer olarak kaydeder ve abonelere iletir.
+// Buffer'ı dolu olan abone beklenmez, düşürülür; böylece yavaş okuyucular
+// toplama döngüsünü bloklayamaz.
+func (s *Store) Publish(m *SystemMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latest = m
+	for sub := range s.subs {
+		select {
+		case sub.ch <- m:
+		default:
+			sub.dropped = true
+			delete(s.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Latest en son snapshot'ı döndürür; henüz snapshot yoksa nil döner.
+// Dönen snapshot okuyucular arasında paylaşılır ve değiştirilmemelidir.
+func (s *Store) Latest() *SystemMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.latest
+}
+
+// Subscribe yeni snapshot'lar için abonelik oluşturur.
+// buffer, abone düşürülmeden önce bekleyebilecek snapshot sayısıdır.
+func (s *Store) Subscribe(buffer int) *Subscription {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	ch := make(chan *SystemMetrics, buffer)
+	sub := &Subscription{C: ch, ch: ch, store: s}
+
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	return sub
+}
+
+// Close aboneliği sonlandırır; birden fazla çağrılabilir
+func (sub *Subscription) Close() {
+	sub.store.mu.Lock()
+	defer sub.store.mu.Unlock()
+
+	if _, ok := sub.store.subs[sub]; ok {
+		delete(sub.store.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Dropped abonenin geride kaldığı için düşürülüp düşürülmediğini döndürür
+func (sub *Subscription) Dropped() bool {
+	sub.store.mu.RLock()
+	defer sub.store.mu.RUnlock()
+
+	return sub.dropped
+}
@@ -0,0 +1,240 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// WatchedProcessMetrics izleme listesindeki bir process'in durumunu içerir
+type WatchedProcessMetrics struct {
+	Name       string    `json:"name"`
+	Up         bool      `json:"up"`
+	PID        int32     `json:"pid,omitempty"`
+	Matches    int       `json:"matches"`              // Eşleşen process sayısı (ör. nginx worker'ları)
+	StartTime  time.Time `json:"start_time,omitempty"` // Ana process'in başlama zamanı
+	Restarts   int       `json:"restarts"`             // Daemon başladığından beri yeniden başlama sayısı
+	CPUPercent float64   `json:"cpu_percent"`
+	RSS        uint64    `json:"rss"`
+	Threads    int32     `json:"threads"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// processWatcher tek bir izleme tanımı ve bu tanımın önceki durumu
+type processWatcher struct {
+	config  config.ProcessWatchConfig
+	cmdline *regexp.Regexp
+	err     error // Tanım geçersizse (ör. hatalı regex) her ölçümde raporlanır
+
+	// Yeniden başlama tespiti ve CPU yüzdesi için önceki ölçüm
+	seen       bool
+	pid        int32
+	createTime int64
+	cpuTime    float64
+	sampleTime time.Time
+	restarts   int
+}
+
+// watchedCandidate bir izleme tanımına eşleşen process
+type watchedCandidate struct {
+	proc       *process.Process
+	createTime int64
+}
+
+// newProcessWatchers config'deki izleme tanımlarından watcher'ları oluşturur
+func newProcessWatchers(watches []config.ProcessWatchConfig) []*processWatcher {
+	watchers := make([]*processWatcher, 0, len(watches))
+	for _, w := range watches {
+		pw := &processWatcher{config: w}
+		if w.Cmdline != "" {
+			re, err := regexp.Compile(w.Cmdline)
+			if err != nil {
+				pw.err = fmt.Errorf("cmdline regex geçersiz: %w", err)
+			}
+			pw.cmdline = re
+		}
+		watchers = append(watchers, pw)
+	}
+	return watchers
+}
+
+// watchlistSubsystem çalışır durumda olması gereken process'leri izler
+type watchlistSubsystem struct {
+	watchers []*processWatcher
+}
+
+// start izleyicileri oluşturur ve başlangıç durumunu alır (restart sayımı buradan itibaren yapılır)
+func (s *watchlistSubsystem) start(cfg config.MetricsConfig) {
+	s.watchers = newProcessWatchers(cfg.Watchlist)
+	s.collect()
+}
+
+func (s *watchlistSubsystem) collectInto(m *SystemMetrics) error {
+	watchMetrics, err := s.collect()
+	m.Watchlist = watchMetrics
+	return err
+}
+
+// collect izleme listesindeki her process'in durumunu kontrol eder
+func (s *watchlistSubsystem) collect() ([]WatchedProcessMetrics, error) {
+	// Pidfile dışındaki eşleşmeler için process listesi bir kez alınır
+	var procs []*process.Process
+	needList := false
+	for _, w := range s.watchers {
+		if w.config.Pidfile == "" {
+			needList = true
+			break
+		}
+	}
+	if needList {
+		var err error
+		procs, err = process.Processes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	result := make([]WatchedProcessMetrics, 0, len(s.watchers))
+	for _, w := range s.watchers {
+		result = append(result, w.check(procs, now))
+	}
+
+	return result, nil
+}
+
+// check watcher'a eşleşen process'leri bulur ve durumu günceller
+func (w *processWatcher) check(procs []*process.Process, now time.Time) WatchedProcessMetrics {
+	m := WatchedProcessMetrics{Name: w.config.Name}
+	if w.err != nil {
+		m.Error = w.err.Error()
+		return m
+	}
+
+	var matches []watchedCandidate
+	if w.config.Pidfile != "" {
+		cand, err := w.matchPidfile()
+		if err != nil {
+			logger.GetLogger().Debugf("Pidfile okunamadı (%s): %v", w.config.Name, err)
+		} else {
+			matches = append(matches, cand)
+		}
+	} else {
+		matches = w.matchList(procs)
+	}
+
+	m.Matches = len(matches)
+	if len(matches) == 0 {
+		// Process kapalı; geri geldiğinde yeniden başlama olarak sayılır
+		w.pid = 0
+		w.createTime = 0
+		m.Restarts = w.restarts
+		return m
+	}
+
+	// Birden fazla eşleşme varsa en eski process ana process kabul edilir
+	primary := matches[0]
+	for _, cand := range matches[1:] {
+		if cand.createTime < primary.createTime {
+			primary = cand
+		}
+	}
+
+	if w.seen && (primary.proc.Pid != w.pid || primary.createTime != w.createTime) {
+		w.restarts++
+		logger.GetLogger().Warnf("İzlenen process yeniden başladı: %s (PID %d)", w.config.Name, primary.proc.Pid)
+	}
+
+	m.Up = true
+	m.PID = primary.proc.Pid
+	if primary.createTime > 0 {
+		m.StartTime = time.UnixMilli(primary.createTime)
+	}
+
+	if times, err := primary.proc.Times(); err == nil {
+		cpuTime := times.User + times.System
+		samePrev := primary.proc.Pid == w.pid && primary.createTime == w.createTime
+		if elapsed := now.Sub(w.sampleTime).Seconds(); samePrev && elapsed > 0 && cpuTime >= w.cpuTime {
+			m.CPUPercent = (cpuTime - w.cpuTime) / elapsed * 100
+		}
+		w.cpuTime = cpuTime
+	}
+	if memInfo, err := primary.proc.MemoryInfo(); err == nil {
+		m.RSS = memInfo.RSS
+	}
+	m.Threads, _ = primary.proc.NumThreads()
+
+	w.seen = true
+	w.pid = primary.proc.Pid
+	w.createTime = primary.createTime
+	w.sampleTime = now
+
+	m.Restarts = w.restarts
+	return m
+}
+
+// matchPidfile pidfile'daki PID'in çalışan bir process'e ait olup olmadığını kontrol eder
+func (w *processWatcher) matchPidfile() (watchedCandidate, error) {
+	data, err := os.ReadFile(w.config.Pidfile)
+	if err != nil {
+		return watchedCandidate{}, err
+	}
+
+	// Bazı servisler (ör. postgres) ilk satırdan sonra ek bilgi yazar
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return watchedCandidate{}, fmt.Errorf("pidfile boş")
+	}
+
+	pid, err := strconv.ParseInt(fields[0], 10, 32)
+	if err != nil {
+		return watchedCandidate{}, fmt.Errorf("pidfile içeriği geçersiz: %w", err)
+	}
+
+	proc, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return watchedCandidate{}, err
+	}
+
+	createTime, _ := proc.CreateTime()
+	return watchedCandidate{proc: proc, createTime: createTime}, nil
+}
+
+// matchList process listesinde isim, exe ve cmdline kriterlerinin hepsine uyanları bulur
+func (w *processWatcher) matchList(procs []*process.Process) []watchedCandidate {
+	var matches []watchedCandidate
+
+	for _, p := range procs {
+		if w.config.ProcessName != "" {
+			name, err := p.Name()
+			if err != nil || name != w.config.ProcessName {
+				continue
+			}
+		}
+		if w.config.Exe != "" {
+			exe, err := p.Exe()
+			if err != nil || filepath.Clean(exe) != filepath.Clean(w.config.Exe) {
+				continue
+			}
+		}
+		if w.cmdline != nil {
+			cmdline, err := p.Cmdline()
+			if err != nil || !w.cmdline.MatchString(cmdline) {
+				continue
+			}
+		}
+
+		createTime, _ := p.CreateTime()
+		matches = append(matches, watchedCandidate{proc: p, createTime: createTime})
+	}
+
+	return matches
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+)
+
+func init() {
+	Register("exec", func() Channel { return &execChannel{} })
+}
+
+// execOptions komut kanalının seçenekleri
+type execOptions struct {
+	Command  string   `json:"command"`
+	Args     []string `json:"args"`
+	Template string   `json:"template"` // stdin şablonu; boşsa olay JSON olarak verilir
+}
+
+// execChannel her olay için yerel bir komut çalıştırır. Olay stdin'den verilir,
+// özet bilgiler SYSWATCH_ALERT_* ortam değişkenleriyle de aktarılır.
+type execChannel struct {
+	opts     execOptions
+	template *template.Template
+}
+
+// NewOptions config doğrulaması için boş seçenek struct'ı döndürür
+func (x *execChannel) NewOptions() interface{} {
+	return &execOptions{}
+}
+
+// Init seçenekleri okur ve stdin şablonunu derler
+func (x *execChannel) Init(options map[string]interface{}) error {
+	if err := decodeOptions(options, &x.opts); err != nil {
+		return err
+	}
+	if x.opts.Command == "" {
+		return fmt.Errorf("exec: command boş olamaz")
+	}
+
+	t, err := parseTemplate("exec", x.opts.Template, "")
+	if err != nil {
+		return err
+	}
+	x.template = t
+	return nil
+}
+
+// Send komutu çalıştırır; sıfırdan farklı çıkış kodu hata sayılır
+func (x *execChannel) Send(ctx context.Context, e Event) error {
+	input, err := render(x.template, e)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, x.opts.Command, x.opts.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(),
+		"SYSWATCH_ALERT_RULE="+e.Rule,
+		"SYSWATCH_ALERT_METRIC="+e.Metric,
+		"SYSWATCH_ALERT_STATE="+string(e.State),
+		"SYSWATCH_ALERT_SEVERITY="+e.Severity,
+		"SYSWATCH_HOSTNAME="+e.Hostname,
+	)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		if out := strings.TrimSpace(output.String()); out != "" {
+			if len(out) > 512 {
+				out = out[:512] + "..."
+			}
+			return fmt.Errorf("%w: %s", err, out)
+		}
+		return err
+	}
+	return nil
+}
+
+// Close kalıcı kaynak tutulmadığı için bir şey yapmaz
+func (x *execChannel) Close() error {
+	return nil
+}
@@ -0,0 +1,415 @@
+// Package notify alarm durum değişikliklerini yapılandırılmış kanallara iletir.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+)
+
+const (
+	defaultTimeout = 10 * time.Second // Tek bir gönderim denemesinin süresi
+	queueSize      = 100              // Kanal başına bekleyen bildirim sınırı
+	maxDeliveries  = 200              // API'de tutulan teslimat kaydı sayısı
+	retryBackoff   = time.Second      // İlk yeniden deneme beklemesi (her denemede iki katına çıkar)
+	maxBackoff     = 30 * time.Second
+)
+
+// Event bildirim kanallarına gönderilen alarm olayı; şablonlarda alanlarına
+// doğrudan erişilebilir ({{.Rule}}, {{.State}}, {{.Hostname}} ...)
+type Event struct {
+	alert.Alert
+	Hostname string `json:"hostname"`
+}
+
+// Channel bir bildirim kanalı.
+// Kanallar Register ile kaydedilir ve config'deki alerts.channels listesi ile etkinleştirilir.
+type Channel interface {
+	// Init config'deki seçeneklerle kanalı hazırlar
+	Init(options map[string]interface{}) error
+	// Send olayı iletir; kalıcı hatalar Permanent ile sarılarak yeniden denemeler durdurulur
+	Send(ctx context.Context, e Event) error
+	// Close kanal kaynaklarını serbest bırakır
+	Close() error
+}
+
+// OptionsChannel seçenek alan kanalların uygulayabileceği arayüz. NewOptions
+// Init'in seçenekleri çözdüğü struct'a yeni bir pointer döndürür; config validate
+// bilinmeyen seçenekleri kanalı başlatmadan bununla bulur.
+type OptionsChannel interface {
+	NewOptions() interface{}
+}
+
+// ChannelFactory yeni bir Channel instance'ı oluşturur
+type ChannelFactory func() Channel
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ChannelFactory)
+)
+
+// Register bir kanal tipini verilen isimle kaydeder; aynı isim iki kez kaydedilirse panic olur
+func Register(name string, factory ChannelFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("notify: Register factory nil: " + name)
+	}
+	if _, exists := registry[name]; exists {
+		panic("notify: Register aynı isimle iki kez çağrıldı: " + name)
+	}
+	registry[name] = factory
+
+	var options config.OptionsFunc
+	if ch, ok := factory().(OptionsChannel); ok {
+		options = ch.NewOptions
+	}
+	config.RegisterChannelType(name, options)
+}
+
+// RegisteredChannels kayıtlı kanal tiplerini sıralı olarak döndürür
+func RegisteredChannels() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// permanentError yeniden denenmemesi gereken hata
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent hatayı yeniden denenmeyecek şekilde işaretler (ör. HTTP 4xx)
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Delivery bir bildirimin teslimat sonucu
+type Delivery struct {
+	Time     time.Time   `json:"time"`
+	Channel  string      `json:"channel"`
+	Rule     string      `json:"rule"`
+	Metric   string      `json:"metric"`
+	State    alert.State `json:"state"`
+	Attempts int         `json:"attempts"`
+	Success  bool        `json:"success"`
+	Error    string      `json:"error,omitempty"`
+	Duration float64     `json:"duration_seconds"`
+}
+
+// activeChannel başlatılmış bir kanal ve filtreleri
+type activeChannel struct {
+	name        string
+	channel     Channel
+	states      map[alert.State]bool
+	minSeverity int
+	timeout     time.Duration
+	retries     int
+	queue       chan Event
+	done        chan struct{} // run goroutine'i bitince kapanır
+}
+
+// Notifier alarm olaylarını kanallara dağıtır. Her kanalın kendi kuyruğu ve
+// goroutine'i vardır; yavaş bir kanal diğerlerini veya toplama döngüsünü bekletmez.
+type Notifier struct {
+	chMu     sync.RWMutex // channels listesini korur (Update ile değişebilir)
+	channels []*activeChannel
+	hostname string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu         sync.RWMutex
+	deliveries []Delivery
+}
+
+// New config'deki kanalları oluşturur ve başlatır.
+// Herhangi bir kanal başlatılamazsa önceden başlatılanlar kapatılır.
+func New(channels []config.NotifyChannelConfig) (*Notifier, error) {
+	hostname, _ := os.Hostname()
+	n := &Notifier{hostname: hostname}
+
+	for _, cc := range channels {
+		ac, err := newActiveChannel(cc)
+		if err != nil {
+			closeChannels(n.channels)
+			return nil, err
+		}
+		n.channels = append(n.channels, ac)
+	}
+
+	return n, nil
+}
+
+// Update kanalları config reload sonrası yeni tanımlarla değiştirir. Yeni kanallardan
+// biri başlatılamazsa mevcut kanallar korunur. Eski kanalların kuyruğundaki
+// bildirimler arka planda gönderildikten sonra kanallar kapatılır.
+func (n *Notifier) Update(channels []config.NotifyChannelConfig) error {
+	var created []*activeChannel
+	for _, cc := range channels {
+		ac, err := newActiveChannel(cc)
+		if err != nil {
+			closeChannels(created)
+			return err
+		}
+		created = append(created, ac)
+	}
+
+	n.chMu.Lock()
+	old := n.channels
+	n.channels = created
+	started := n.ctx != nil
+	if started {
+		n.startChannels(created)
+	}
+	n.chMu.Unlock()
+
+	// Swap kilit altında yapıldığından eski kuyruklara artık yazılmaz
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		for _, ac := range old {
+			close(ac.queue)
+		}
+		if started {
+			for _, ac := range old {
+				<-ac.done
+			}
+		}
+		closeChannels(old)
+	}()
+	return nil
+}
+
+// newActiveChannel config'deki tanımdan kanal oluşturur
+func newActiveChannel(cc config.NotifyChannelConfig) (*activeChannel, error) {
+	registryMu.RLock()
+	factory, ok := registry[cc.Type]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("bilinmeyen bildirim kanalı tipi: %s (kayıtlı tipler: %v)", cc.Type, RegisteredChannels())
+	}
+
+	ch := factory()
+	if err := ch.Init(cc.Options); err != nil {
+		return nil, fmt.Errorf("bildirim kanalı başlatılamadı (%s): %w", cc.Name, err)
+	}
+
+	ac := &activeChannel{
+		name:        cc.Name,
+		channel:     ch,
+		states:      map[alert.State]bool{alert.StateFiring: true, alert.StateResolved: true},
+		minSeverity: severityLevel(cc.MinSeverity),
+		timeout:     time.Duration(cc.Timeout) * time.Second,
+		retries:     cc.Retries,
+		queue:       make(chan Event, queueSize),
+		done:        make(chan struct{}),
+	}
+	if len(cc.States) > 0 {
+		ac.states = make(map[alert.State]bool)
+		for _, s := range cc.States {
+			ac.states[alert.State(s)] = true
+		}
+	}
+	if ac.timeout <= 0 {
+		ac.timeout = defaultTimeout
+	}
+	return ac, nil
+}
+
+// Start kanal goroutine'lerini başlatır
+func (n *Notifier) Start() {
+	n.chMu.Lock()
+	defer n.chMu.Unlock()
+
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+	n.startChannels(n.channels)
+}
+
+// startChannels kanalların goroutine'lerini başlatır (çağıran chMu'yu tutmalı)
+func (n *Notifier) startChannels(channels []*activeChannel) {
+	log := logger.GetLogger()
+	for _, ac := range channels {
+		n.wg.Add(1)
+		go n.run(ac)
+		log.Infof("Bildirim kanalı başlatıldı: %s", ac.name)
+	}
+}
+
+// Stop kuyruktaki bildirimlerin gönderilmesini ctx süresince bekler; süre dolarsa
+// devam eden gönderimler iptal edilir. Start çağrılmadan veya birden fazla kez
+// çağrılabilir.
+func (n *Notifier) Stop(ctx context.Context) {
+	n.chMu.Lock()
+	channels := n.channels
+	n.channels = nil
+	for _, ac := range channels {
+		close(ac.queue)
+	}
+	cancel := n.cancel
+	n.chMu.Unlock()
+
+	// Start çağrılmadıysa iptal edilecek gönderim yoktur
+	if cancel == nil {
+		cancel = func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.GetLogger().Warn("Bildirim kuyrukları boşaltılamadan kapatılıyor")
+		cancel()
+		<-done
+	}
+	cancel()
+	closeChannels(channels)
+}
+
+// closeChannels verilen kanalları kapatır
+func closeChannels(channels []*activeChannel) {
+	for _, ac := range channels {
+		if err := ac.channel.Close(); err != nil {
+			logger.GetLogger().Errorf("Bildirim kanalı kapatılırken hata (%s): %v", ac.name, err)
+		}
+	}
+}
+
+// Notify alarm geçişlerini ilgili kanalların kuyruğuna ekler; bloklamaz.
+// Kuyruğu dolu olan kanal için bildirim düşürülür ve başarısız teslimat olarak kaydedilir.
+func (n *Notifier) Notify(alerts []alert.Alert) {
+	n.chMu.RLock()
+	defer n.chMu.RUnlock()
+
+	for _, a := range alerts {
+		e := Event{Alert: a, Hostname: n.hostname}
+		for _, ac := range n.channels {
+			if !ac.states[a.State] || severityLevel(a.Severity) < ac.minSeverity {
+				continue
+			}
+			select {
+			case ac.queue <- e:
+			default:
+				n.record(ac, e, 0, 0, errors.New("bildirim kuyruğu dolu, olay düşürüldü"))
+			}
+		}
+	}
+}
+
+// run kanalın kuyruğundaki olayları sırayla gönderir
+func (n *Notifier) run(ac *activeChannel) {
+	defer n.wg.Done()
+	defer close(ac.done)
+
+	for e := range ac.queue {
+		start := time.Now()
+		attempts, err := n.deliver(ac, e)
+		n.record(ac, e, attempts, time.Since(start), err)
+	}
+}
+
+// deliver olayı gerekirse üstel bekleme ile yeniden deneyerek gönderir
+func (n *Notifier) deliver(ac *activeChannel, e Event) (int, error) {
+	backoff := retryBackoff
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(n.ctx, ac.timeout)
+		err = ac.channel.Send(ctx, e)
+		cancel()
+
+		var perm *permanentError
+		if err == nil || errors.As(err, &perm) || attempt > ac.retries {
+			return attempt, err
+		}
+
+		logger.GetLogger().Debugf("Bildirim başarısız, %s sonra yeniden denenecek (%s): %v", backoff, ac.name, err)
+		select {
+		case <-time.After(backoff):
+		case <-n.ctx.Done():
+			return attempt, err
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// record teslimat sonucunu loglar ve API için saklar
+func (n *Notifier) record(ac *activeChannel, e Event, attempts int, duration time.Duration, err error) {
+	d := Delivery{
+		Time:     time.Now(),
+		Channel:  ac.name,
+		Rule:     e.Rule,
+		Metric:   e.Metric,
+		State:    e.State,
+		Attempts: attempts,
+		Success:  err == nil,
+		Duration: duration.Seconds(),
+	}
+
+	log := logger.GetLogger()
+	if err != nil {
+		d.Error = err.Error()
+		log.Errorf("Bildirim gönderilemedi (%s, %s/%s, %d deneme): %v", ac.name, e.Rule, e.State, attempts, err)
+	} else {
+		log.Infof("Bildirim gönderildi (%s, %s/%s)", ac.name, e.Rule, e.State)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.deliveries = append(n.deliveries, d)
+	if len(n.deliveries) > maxDeliveries {
+		n.deliveries = n.deliveries[len(n.deliveries)-maxDeliveries:]
+	}
+}
+
+// Deliveries son teslimat kayıtlarını yeniden eskiye döndürür
+func (n *Notifier) Deliveries() []Delivery {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	result := make([]Delivery, len(n.deliveries))
+	for i, d := range n.deliveries {
+		result[len(result)-1-i] = d
+	}
+	return result
+}
+
+// severityLevel önem seviyesini karşılaştırma için sayıya çevirir (boş = tümü)
+func severityLevel(severity string) int {
+	switch severity {
+	case alert.SeverityCritical:
+		return 2
+	case alert.SeverityWarning:
+		return 1
+	}
+	return 0
+}
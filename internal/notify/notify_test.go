@@ -0,0 +1,365 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+	"github.com/karsterr/syswatch-daemon/internal/config"
+)
+
+// testAlert bildirim testlerinde kullanılan firing alarm
+func testAlert() alert.Alert {
+	return alert.Alert{
+		Rule:      "disk_full",
+		Metric:    "disk.filesystems./data.usage",
+		State:     alert.StateFiring,
+		Severity:  alert.SeverityCritical,
+		Summary:   "Disk dolmak üzere",
+		Value:     95.5,
+		Threshold: 90,
+	}
+}
+
+// waitDeliveries notifier'da en az n teslimat kaydı oluşmasını bekler
+func waitDeliveries(t *testing.T, n *Notifier, count int) []Delivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if d := n.Deliveries(); len(d) >= count {
+			return d
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d deliveries, got %v", count, n.Deliveries())
+	return nil
+}
+
+func TestRender(t *testing.T) {
+	tmpl, err := parseTemplate("test", "", defaultMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := render(tmpl, Event{Alert: testAlert(), Hostname: "web1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[FIRING] disk_full (critical) - web1: Disk dolmak üzere (değer: 95.50, eşik: 90)"
+	if string(out) != expected {
+		t.Errorf("Unexpected message:\n got: %s\nwant: %s", out, expected)
+	}
+
+	// Şablon yoksa olay JSON olarak gönderilir
+	out, err = render(nil, Event{Alert: testAlert(), Hostname: "web1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("Expected JSON body, got %s", out)
+	}
+	if decoded["rule"] != "disk_full" || decoded["hostname"] != "web1" {
+		t.Errorf("Unexpected JSON body: %s", out)
+	}
+
+	if _, err := parseTemplate("bad", "{{.Rule", ""); err == nil {
+		t.Error("Expected error for invalid template")
+	}
+}
+
+func TestWebhookRetryAndSignature(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		expected := "sha256=" + Sign("s3cret", r.Header.Get(TimestampHeader), body)
+		if r.Header.Get(SignatureHeader) != expected {
+			t.Errorf("Invalid signature: %s", r.Header.Get(SignatureHeader))
+		}
+		if r.Header.Get("X-Team") != "ops" {
+			t.Errorf("Missing custom header")
+		}
+		// İlk deneme başarısız olur, ikincisi kabul edilir
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n, err := New([]config.NotifyChannelConfig{{
+		Name:    "hook",
+		Type:    "webhook",
+		Retries: 2,
+		Options: map[string]interface{}{
+			"url":     srv.URL,
+			"secret":  "s3cret",
+			"headers": map[string]interface{}{"X-Team": "ops"},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Start()
+	defer n.Stop(context.Background())
+
+	n.Notify([]alert.Alert{testAlert()})
+	d := waitDeliveries(t, n, 1)[0]
+	if !d.Success || d.Attempts != 2 || d.Channel != "hook" || d.Rule != "disk_full" {
+		t.Errorf("Unexpected delivery: %+v", d)
+	}
+}
+
+func TestWebhookPermanentError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	n, err := New([]config.NotifyChannelConfig{{
+		Name:    "hook",
+		Type:    "webhook",
+		Retries: 3,
+		Options: map[string]interface{}{"url": srv.URL},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Start()
+	defer n.Stop(context.Background())
+
+	n.Notify([]alert.Alert{testAlert()})
+	d := waitDeliveries(t, n, 1)[0]
+	if d.Success || d.Attempts != 1 || !strings.Contains(d.Error, "400") {
+		t.Errorf("Expected single failed attempt, got %+v", d)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Expected 4xx not to be retried, got %d calls", calls)
+	}
+}
+
+func TestChannelFilters(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+
+	n, err := New([]config.NotifyChannelConfig{{
+		Name:        "hook",
+		Type:        "webhook",
+		States:      []string{"firing"},
+		MinSeverity: "critical",
+		Options:     map[string]interface{}{"url": srv.URL},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Start()
+
+	warning := testAlert()
+	warning.Severity = alert.SeverityWarning
+	resolved := testAlert()
+	resolved.State = alert.StateResolved
+	n.Notify([]alert.Alert{warning, resolved, testAlert()})
+
+	n.Stop(context.Background())
+	if calls != 1 || len(n.Deliveries()) != 1 {
+		t.Errorf("Expected only the critical firing alert to be sent, got %d calls", calls)
+	}
+}
+
+func TestUnknownChannel(t *testing.T) {
+	if _, err := New([]config.NotifyChannelConfig{{Name: "x", Type: "pager"}}); err == nil {
+		t.Error("Expected error for unknown channel type")
+	}
+	if _, err := New([]config.NotifyChannelConfig{{Name: "x", Type: "webhook"}}); err == nil {
+		t.Error("Expected error for webhook without url")
+	}
+}
+
+func TestStopWithoutStart(t *testing.T) {
+	n, err := New([]config.NotifyChannelConfig{{Name: "ops", Type: "webhook", Options: map[string]interface{}{"url": "http://127.0.0.1:1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Start çağrılmadan ve iki kez durdurmak panic olmamalı
+	n.Stop(context.Background())
+	n.Stop(context.Background())
+}
+
+func TestUpdateChannels(t *testing.T) {
+	var oldCalls, newCalls int32
+	oldSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&oldCalls, 1)
+	}))
+	defer oldSrv.Close()
+	newSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&newCalls, 1)
+	}))
+	defer newSrv.Close()
+
+	n, err := New([]config.NotifyChannelConfig{{Name: "old", Type: "webhook", Options: map[string]interface{}{"url": oldSrv.URL}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Start()
+	n.Notify([]alert.Alert{testAlert()})
+
+	// Geçersiz güncelleme mevcut kanalları değiştirmemeli
+	if err := n.Update([]config.NotifyChannelConfig{{Name: "bad", Type: "webhook"}}); err == nil {
+		t.Fatal("Expected error for invalid channel update")
+	}
+
+	if err := n.Update([]config.NotifyChannelConfig{{Name: "new", Type: "webhook", Options: map[string]interface{}{"url": newSrv.URL}}}); err != nil {
+		t.Fatal(err)
+	}
+	n.Notify([]alert.Alert{testAlert()})
+	n.Stop(context.Background())
+
+	if atomic.LoadInt32(&oldCalls) != 1 || atomic.LoadInt32(&newCalls) != 1 {
+		t.Errorf("Expected one delivery per channel generation, got old=%d new=%d", oldCalls, newCalls)
+	}
+	for _, d := range n.Deliveries() {
+		if !d.Success {
+			t.Errorf("Unexpected failed delivery: %+v", d)
+		}
+	}
+}
+
+// fakeSMTP tek bir e-postayı kabul eden minimal SMTP sunucusu; alınan DATA içeriğini döndürür
+func fakeSMTP(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(chan string, 1)
+
+	go func() {
+		defer ln.Close()
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { io.WriteString(conn, s+"\r\n") }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				received <- data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), received
+}
+
+func TestSMTPChannel(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	host, portStr, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portStr)
+
+	ch := &smtpChannel{}
+	err := ch.Init(map[string]interface{}{
+		"host":     host,
+		"port":     port,
+		"from":     "syswatch@example.com",
+		"to":       []interface{}{"ops@example.com"},
+		"subject":  "{{.Rule}} {{.State}}",
+		"template": "Değer: {{.Value}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ch.Send(ctx, Event{Alert: testAlert(), Hostname: "web1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := <-received
+	for _, want := range []string{"To: ops@example.com", "Subject: disk_full firing", "Değer: 95.5"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Message missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestExecChannel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh gerektirir")
+	}
+	out := filepath.Join(t.TempDir(), "alert.json")
+
+	ch := &execChannel{}
+	err := ch.Init(map[string]interface{}{
+		"command": "sh",
+		"args":    []interface{}{"-c", `cat > "$0"; test "$SYSWATCH_ALERT_STATE" = firing`, out},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ch.Send(context.Background(), Event{Alert: testAlert(), Hostname: "web1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil || e.Rule != "disk_full" || e.Hostname != "web1" {
+		t.Errorf("Unexpected stdin payload: %s", data)
+	}
+
+	// Sıfırdan farklı çıkış kodu çıktıyla birlikte hata olarak dönmeli
+	ch.opts.Args = []string{"-c", "echo boom >&2; exit 3"}
+	if err := ch.Send(context.Background(), Event{Alert: testAlert()}); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected command failure with output, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+func init() {
+	Register("smtp", func() Channel { return &smtpChannel{} })
+}
+
+// smtpOptions e-posta kanalının seçenekleri
+type smtpOptions struct {
+	Host               string   `json:"host"`
+	Port               int      `json:"port"` // Varsayılan 25
+	Username           string   `json:"username"`
+	Password           string   `json:"password"`
+	From               string   `json:"from"`
+	To                 []string `json:"to"`
+	Subject            string   `json:"subject"`              // Konu şablonu
+	Template           string   `json:"template"`             // Gövde şablonu
+	InsecureSkipVerify bool     `json:"insecure_skip_verify"` // STARTTLS sertifikası doğrulanmaz
+}
+
+// smtpChannel olayı e-posta olarak gönderir. Sunucu destekliyorsa STARTTLS kullanılır.
+type smtpChannel struct {
+	opts    smtpOptions
+	subject *template.Template
+	body    *template.Template
+}
+
+// NewOptions config doğrulaması için boş seçenek struct'ı döndürür
+func (s *smtpChannel) NewOptions() interface{} {
+	return &smtpOptions{}
+}
+
+// Init seçenekleri okur ve şablonları derler
+func (s *smtpChannel) Init(options map[string]interface{}) error {
+	s.opts = smtpOptions{Port: 25}
+	if err := decodeOptions(options, &s.opts); err != nil {
+		return err
+	}
+	if s.opts.Host == "" || s.opts.From == "" || len(s.opts.To) == 0 {
+		return fmt.Errorf("smtp: host, from ve to boş olamaz")
+	}
+
+	var err error
+	if s.subject, err = parseTemplate("subject", s.opts.Subject, defaultSubject); err != nil {
+		return err
+	}
+	if s.body, err = parseTemplate("smtp", s.opts.Template, defaultMessage); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Send e-postayı gönderir
+func (s *smtpChannel) Send(ctx context.Context, e Event) error {
+	subject, err := render(s.subject, e)
+	if err != nil {
+		return err
+	}
+	body, err := render(s.body, e)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.opts.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.opts.Host, InsecureSkipVerify: s.opts.InsecureSkipVerify}); err != nil {
+			return err
+		}
+	}
+	if s.opts.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.opts.Username, s.opts.Password, s.opts.Host)); err != nil {
+			return Permanent(err)
+		}
+	}
+
+	if err := c.Mail(s.opts.From); err != nil {
+		return err
+	}
+	for _, to := range s.opts.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.message(string(subject), body)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// message e-posta header'larını ve gövdesini oluşturur
+func (s *smtpChannel) message(subject string, body []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.opts.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(s.opts.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject)))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// Close kalıcı bağlantı tutulmadığı için bir şey yapmaz
+func (s *smtpChannel) Close() error {
+	return nil
+}
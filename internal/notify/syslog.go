@@ -0,0 +1,136 @@
+//go:build !windows
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+)
+
+func init() {
+	Register("syslog", func() Channel { return &syslogChannel{} })
+}
+
+// syslogOptions syslog kanalının seçenekleri
+type syslogOptions struct {
+	Network  string `json:"network"`  // Boşsa yerel syslog soketi; "udp" veya "tcp" ile uzak sunucu
+	Address  string `json:"address"`  // Uzak sunucu adresi (host:port)
+	Tag      string `json:"tag"`      // Varsayılan syswatch
+	Facility string `json:"facility"` // daemon, user, local0..local7 (varsayılan daemon)
+	Template string `json:"template"` // Mesaj şablonu
+}
+
+// syslogFacilities desteklenen facility isimleri
+var syslogFacilities = map[string]syslog.Priority{
+	"daemon": syslog.LOG_DAEMON,
+	"user":   syslog.LOG_USER,
+	"local0": syslog.LOG_LOCAL0,
+	"local1": syslog.LOG_LOCAL1,
+	"local2": syslog.LOG_LOCAL2,
+	"local3": syslog.LOG_LOCAL3,
+	"local4": syslog.LOG_LOCAL4,
+	"local5": syslog.LOG_LOCAL5,
+	"local6": syslog.LOG_LOCAL6,
+	"local7": syslog.LOG_LOCAL7,
+}
+
+// syslogChannel olayı syslog'a yazar. Öncelik alarmın önem seviyesinden belirlenir;
+// kapanan alarmlar notice olarak yazılır.
+type syslogChannel struct {
+	opts     syslogOptions
+	facility syslog.Priority
+	template *template.Template
+
+	mu     sync.Mutex
+	writer *syslog.Writer
+}
+
+// NewOptions config doğrulaması için boş seçenek struct'ı döndürür
+func (s *syslogChannel) NewOptions() interface{} {
+	return &syslogOptions{}
+}
+
+// Init seçenekleri okur ve syslog bağlantısını açar
+func (s *syslogChannel) Init(options map[string]interface{}) error {
+	s.opts = syslogOptions{Tag: "syswatch", Facility: "daemon"}
+	if err := decodeOptions(options, &s.opts); err != nil {
+		return err
+	}
+
+	facility, ok := syslogFacilities[strings.ToLower(s.opts.Facility)]
+	if !ok {
+		return fmt.Errorf("syslog: geçersiz facility: %s", s.opts.Facility)
+	}
+	s.facility = facility
+
+	t, err := parseTemplate("syslog", s.opts.Template, defaultMessage)
+	if err != nil {
+		return err
+	}
+	s.template = t
+
+	return s.connect()
+}
+
+// connect syslog bağlantısını (yeniden) açar
+func (s *syslogChannel) connect() error {
+	w, err := syslog.Dial(s.opts.Network, s.opts.Address, s.facility|syslog.LOG_INFO, s.opts.Tag)
+	if err != nil {
+		return fmt.Errorf("syslog bağlantısı açılamadı: %w", err)
+	}
+	s.writer = w
+	return nil
+}
+
+// Send mesajı yazar; bağlantı kopmuşsa bir sonraki denemede yeniden açılır
+func (s *syslogChannel) Send(ctx context.Context, e Event) error {
+	msg, err := render(s.template, e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+
+	text := string(msg)
+	switch {
+	case e.State == alert.StateResolved:
+		err = s.writer.Notice(text)
+	case e.Severity == alert.SeverityCritical:
+		err = s.writer.Crit(text)
+	case e.Severity == alert.SeverityWarning:
+		err = s.writer.Warning(text)
+	default:
+		err = s.writer.Info(text)
+	}
+	if err != nil {
+		s.writer.Close()
+		s.writer = nil
+	}
+	return err
+}
+
+// Close syslog bağlantısını kapatır
+func (s *syslogChannel) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer == nil {
+		return nil
+	}
+	err := s.writer.Close()
+	s.writer = nil
+	return err
+}
@@ -0,0 +1,54 @@
+//go:build !windows
+
+package notify
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogChannel(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ch := &syslogChannel{}
+	err = ch.Init(map[string]interface{}{
+		"network":  "udp",
+		"address":  conn.LocalAddr().String(),
+		"facility": "local3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ch.Close()
+
+	if err := ch.Send(context.Background(), Event{Alert: testAlert(), Hostname: "web1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 2048)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := string(buf[:n])
+
+	// local3 (19) * 8 + crit (2) = 154
+	if !strings.HasPrefix(msg, "<154>") {
+		t.Errorf("Unexpected priority: %s", msg)
+	}
+	if !strings.Contains(msg, "syswatch") || !strings.Contains(msg, "[FIRING] disk_full") {
+		t.Errorf("Unexpected syslog message: %s", msg)
+	}
+
+	if err := (&syslogChannel{}).Init(map[string]interface{}{"facility": "kern2"}); err == nil {
+		t.Error("Expected error for invalid facility")
+	}
+}
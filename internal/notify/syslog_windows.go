@@ -0,0 +1,30 @@
+//go:build windows
+
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	Register("syslog", func() Channel { return &syslogChannel{} })
+}
+
+// syslogChannel Windows'ta syslog bulunmadığından başlatılamaz
+type syslogChannel struct{}
+
+// Init her zaman hata döndürür
+func (s *syslogChannel) Init(options map[string]interface{}) error {
+	return fmt.Errorf("syslog kanalı Windows'ta desteklenmiyor")
+}
+
+// Send kullanılmaz
+func (s *syslogChannel) Send(ctx context.Context, e Event) error {
+	return fmt.Errorf("syslog kanalı Windows'ta desteklenmiyor")
+}
+
+// Close bir şey yapmaz
+func (s *syslogChannel) Close() error {
+	return nil
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/karsterr/syswatch-daemon/internal/config"
+)
+
+// Varsayılan mesaj şablonları
+const (
+	defaultSubject = `[{{upper .State}}] {{.Rule}} - {{.Hostname}}`
+	defaultMessage = `[{{upper .State}}] {{.Rule}} ({{.Severity}}) - {{.Hostname}}: {{.Summary}} (değer: {{printf "%.2f" .Value}}, eşik: {{printf "%g" .Threshold}})`
+)
+
+// templateFuncs şablonlarda kullanılabilen yardımcı fonksiyonlar
+var templateFuncs = template.FuncMap{
+	"upper": func(v interface{}) string { return strings.ToUpper(fmt.Sprint(v)) },
+	"lower": func(v interface{}) string { return strings.ToLower(fmt.Sprint(v)) },
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// parseTemplate şablonu derler; text boşsa fallback kullanılır.
+// fallback da boşsa nil döner (kanal varsayılan JSON gövdeyi kullanır).
+func parseTemplate(name, text, fallback string) (*template.Template, error) {
+	if text == "" {
+		text = fallback
+	}
+	if text == "" {
+		return nil, nil
+	}
+	t, err := template.New(name).Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s şablonu geçersiz: %w", name, err)
+	}
+	return t, nil
+}
+
+// render şablonu olayla çalıştırır; şablon nil ise olay JSON olarak döner
+func render(t *template.Template, e Event) ([]byte, error) {
+	if t == nil {
+		return json.Marshal(e)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, e); err != nil {
+		return nil, Permanent(fmt.Errorf("%s şablonu çalıştırılamadı: %w", t.Name(), err))
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeOptions config'deki seçenek map'ini kanalın seçenek struct'ına dönüştürür.
+// Struct'ta olmayan seçenekler hata döndürür.
+func decodeOptions(options map[string]interface{}, target interface{}) error {
+	if err := config.DecodeOptions(options, target); err != nil {
+		return fmt.Errorf("kanal seçenekleri geçersiz: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+// SignatureHeader webhook gövdesinin HMAC-SHA256 imzasını taşıyan header.
+// Değer "sha256=<hex>" biçimindedir; imza "<timestamp>.<gövde>" üzerinden hesaplanır.
+const (
+	SignatureHeader = "X-Syswatch-Signature"
+	TimestampHeader = "X-Syswatch-Timestamp"
+)
+
+func init() {
+	Register("webhook", func() Channel { return &webhookChannel{} })
+}
+
+// webhookOptions webhook kanalının seçenekleri
+type webhookOptions struct {
+	URL         string            `json:"url"`
+	Method      string            `json:"method"`       // Varsayılan POST
+	Headers     map[string]string `json:"headers"`      // Ek HTTP header'ları
+	Secret      string            `json:"secret"`       // Verilirse gövde HMAC-SHA256 ile imzalanır
+	Template    string            `json:"template"`     // Gövde şablonu; boşsa olay JSON olarak gönderilir
+	ContentType string            `json:"content_type"` // Varsayılan application/json
+}
+
+// webhookChannel olayı bir HTTP uç noktasına gönderir
+type webhookChannel struct {
+	opts     webhookOptions
+	template *template.Template
+	client   *http.Client
+}
+
+// NewOptions config doğrulaması için boş seçenek struct'ı döndürür
+func (w *webhookChannel) NewOptions() interface{} {
+	return &webhookOptions{}
+}
+
+// Init seçenekleri okur ve gövde şablonunu derler
+func (w *webhookChannel) Init(options map[string]interface{}) error {
+	w.opts = webhookOptions{Method: http.MethodPost, ContentType: "application/json"}
+	if err := decodeOptions(options, &w.opts); err != nil {
+		return err
+	}
+	if w.opts.URL == "" {
+		return fmt.Errorf("webhook: url boş olamaz")
+	}
+
+	t, err := parseTemplate("webhook", w.opts.Template, "")
+	if err != nil {
+		return err
+	}
+	w.template = t
+	w.client = &http.Client{}
+	return nil
+}
+
+// Send olayı gönderir. 5xx ve 429 yanıtları ile ağ hataları yeniden denenir,
+// diğer 4xx yanıtları kalıcı hata sayılır.
+func (w *webhookChannel) Send(ctx context.Context, e Event) error {
+	body, err := render(w.template, e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, w.opts.Method, w.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(err)
+	}
+	req.Header.Set("Content-Type", w.opts.ContentType)
+	req.Header.Set("User-Agent", "syswatch-daemon")
+	for k, v := range w.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	if w.opts.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, ts)
+		req.Header.Set(SignatureHeader, "sha256="+Sign(w.opts.Secret, ts, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("webhook yanıtı: %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
+
+// Close bekleyen bağlantıları kapatır
+func (w *webhookChannel) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+// Sign webhook imzasını hesaplar; alıcı taraf doğrulama için aynı fonksiyonu kullanabilir
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,362 @@
+// Package silence alarm bildirimlerini API ile oluşturulan susturmalar ve
+// config'deki bakım pencereleri süresince bastırır. Alarm durumları etkilenmez.
+package silence
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+	"github.com/karsterr/syswatch-daemon/internal/config"
+	"github.com/karsterr/syswatch-daemon/internal/cron"
+)
+
+// expiredRetention süresi dolan susturmaların listede kalma süresi
+const expiredRetention = 24 * time.Hour
+
+// ErrNotFound verilen ID ile susturma bulunamadı
+var ErrNotFound = errors.New("susturma bulunamadı")
+
+// Silence belirli bir zaman aralığında eşleşen alarmların bildirimlerini bastırır
+type Silence struct {
+	ID        string            `json:"id"`
+	Rule      string            `json:"rule,omitempty"`   // Kural ismi (* joker); boşsa tüm kurallar
+	Labels    map[string]string `json:"labels,omitempty"` // Alarmda aynı değerle bulunması gereken etiketler
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    time.Time         `json:"ends_at"`
+	CreatedBy string            `json:"created_by"`
+	Comment   string            `json:"comment"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Active susturmanın verilen zamanda geçerli olup olmadığını döndürür
+func (s *Silence) Active(now time.Time) bool {
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt)
+}
+
+// Window bir bakım penceresinin durumu
+type Window struct {
+	Name      string            `json:"name"`
+	Schedule  string            `json:"schedule"`
+	Duration  int               `json:"duration"`
+	Rules     []string          `json:"rules,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Active    bool              `json:"active"`
+	EndsAt    *time.Time        `json:"ends_at,omitempty"`    // Aktif pencerenin bitişi
+	NextStart *time.Time        `json:"next_start,omitempty"` // Bir sonraki başlangıç
+}
+
+// window derlenmiş bakım penceresi
+type window struct {
+	config.MaintenanceWindowConfig
+	schedule *cron.Schedule
+}
+
+// Silencer susturmaları ve bakım pencerelerini tutar. API ile eklenen
+// susturmalar her değişiklikte dosyaya yazılır ve açılışta geri yüklenir.
+type Silencer struct {
+	mu       sync.RWMutex
+	path     string
+	silences map[string]*Silence
+	windows  []*window
+}
+
+// New bakım pencerelerini derler ve kayıtlı susturmaları dosyadan yükler.
+// path boşsa susturmalar sadece bellekte tutulur.
+func New(path string, windows []config.MaintenanceWindowConfig) (*Silencer, error) {
+	s := &Silencer{path: path, silences: make(map[string]*Silence)}
+
+	if err := s.SetWindows(windows); err != nil {
+		return nil, err
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetWindows bakım pencerelerini değiştirir; geçersiz zamanlama varsa mevcut pencereler korunur
+func (s *Silencer) SetWindows(windows []config.MaintenanceWindowConfig) error {
+	compiled := make([]*window, 0, len(windows))
+	for _, wc := range windows {
+		schedule, err := cron.Parse(wc.Schedule)
+		if err != nil {
+			return fmt.Errorf("bakım penceresi %s: %w", wc.Name, err)
+		}
+		compiled = append(compiled, &window{MaintenanceWindowConfig: wc, schedule: schedule})
+	}
+
+	s.mu.Lock()
+	s.windows = compiled
+	s.mu.Unlock()
+	return nil
+}
+
+// load susturmaları dosyadan okur; dosya yoksa boş liste ile başlar
+func (s *Silencer) load() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("susturma dosyası okunamadı: %w", err)
+	}
+
+	var silences []*Silence
+	if err := json.Unmarshal(data, &silences); err != nil {
+		return fmt.Errorf("susturma dosyası geçersiz (%s): %w", s.path, err)
+	}
+	for _, sil := range silences {
+		s.silences[sil.ID] = sil
+	}
+	s.prune(time.Now())
+	return nil
+}
+
+// save susturmaları dosyaya atomik olarak yazar (çağıran kilidi tutmalı)
+func (s *Silencer) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.list(true, time.Now()), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("susturma dizini oluşturulamadı: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("susturma dosyası yazılamadı: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("susturma dosyası yazılamadı: %w", err)
+	}
+	return nil
+}
+
+// Add yeni bir susturma ekler ve dosyaya kaydeder.
+// ID, CreatedAt ve boş bırakılan StartsAt (şimdi) doldurulur.
+func (s *Silencer) Add(sil Silence) (Silence, error) {
+	now := time.Now()
+	if sil.StartsAt.IsZero() {
+		sil.StartsAt = now
+	}
+	if err := validate(&sil, now); err != nil {
+		return Silence{}, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Silence{}, err
+	}
+	sil.ID = id
+	sil.CreatedAt = now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	s.silences[sil.ID] = &sil
+	if err := s.save(); err != nil {
+		delete(s.silences, sil.ID)
+		return Silence{}, err
+	}
+	return sil, nil
+}
+
+// Delete susturmayı kaldırır
+func (s *Silencer) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sil, ok := s.silences[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.silences, id)
+	if err := s.save(); err != nil {
+		s.silences[id] = sil
+		return err
+	}
+	return nil
+}
+
+// List susturmaları başlangıç zamanına göre sıralı döndürür.
+// includeExpired false ise süresi dolanlar listelenmez.
+func (s *Silencer) List(includeExpired bool) []Silence {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list(includeExpired, time.Now())
+}
+
+// list kilit tutulurken susturmaları listeler
+func (s *Silencer) list(includeExpired bool, now time.Time) []Silence {
+	result := make([]Silence, 0, len(s.silences))
+	for _, sil := range s.silences {
+		if includeExpired || now.Before(sil.EndsAt) {
+			result = append(result, *sil)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].StartsAt.Equal(result[j].StartsAt) {
+			return result[i].StartsAt.Before(result[j].StartsAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// prune uzun süre önce dolan susturmaları siler (çağıran kilidi tutmalı)
+func (s *Silencer) prune(now time.Time) {
+	for id, sil := range s.silences {
+		if now.Sub(sil.EndsAt) > expiredRetention {
+			delete(s.silences, id)
+		}
+	}
+}
+
+// Windows bakım pencerelerini verilen zamandaki durumlarıyla döndürür
+func (s *Silencer) Windows(now time.Time) []Window {
+	s.mu.RLock()
+	windows := s.windows
+	s.mu.RUnlock()
+
+	result := make([]Window, 0, len(windows))
+	for _, w := range windows {
+		status := Window{
+			Name:     w.Name,
+			Schedule: w.Schedule,
+			Duration: w.Duration,
+			Rules:    w.Rules,
+			Labels:   w.Labels,
+		}
+		if start, ok := w.lastStart(now); ok {
+			end := start.Add(w.duration())
+			status.Active = true
+			status.EndsAt = &end
+		}
+		if next, ok := w.schedule.Next(now); ok {
+			status.NextStart = &next
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// Silenced alarmı bastıran susturmanın ID'sini veya "maintenance:<isim>" döndürür.
+// Alarm bastırılmıyorsa boş string döner.
+func (s *Silencer) Silenced(a alert.Alert, now time.Time) string {
+	s.mu.RLock()
+	var ids []string
+	for id, sil := range s.silences {
+		if sil.Active(now) && matches(sil.Rule, sil.Labels, a) {
+			ids = append(ids, id)
+		}
+	}
+	windows := s.windows
+	s.mu.RUnlock()
+
+	if len(ids) > 0 {
+		// Birden fazla susturma eşleşirse sonuç deterministik olsun
+		sort.Strings(ids)
+		return ids[0]
+	}
+
+	for _, w := range windows {
+		if !w.matches(a) {
+			continue
+		}
+		if _, ok := w.lastStart(now); ok {
+			return "maintenance:" + w.Name
+		}
+	}
+	return ""
+}
+
+// duration pencere süresini döndürür
+func (w *window) duration() time.Duration {
+	return time.Duration(w.Duration) * time.Second
+}
+
+// lastStart şu an aktif olan pencere dönemi varsa başlangıcını döndürür
+func (w *window) lastStart(now time.Time) (time.Time, bool) {
+	return w.schedule.LastStart(now, w.duration())
+}
+
+// matches alarmın pencerenin kural ve etiket filtrelerine uyup uymadığını döndürür
+func (w *window) matches(a alert.Alert) bool {
+	if len(w.Rules) == 0 {
+		return matches("", w.Labels, a)
+	}
+	for _, r := range w.Rules {
+		if matches(r, w.Labels, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches kural deseni ve etiketlerin alarmla eşleşip eşleşmediğini döndürür
+func matches(rule string, labels map[string]string, a alert.Alert) bool {
+	if rule != "" {
+		if ok, _ := path.Match(rule, a.Rule); !ok {
+			return false
+		}
+	}
+	for k, v := range labels {
+		if a.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// validate yeni susturmayı kontrol eder
+func validate(sil *Silence, now time.Time) error {
+	if sil.CreatedBy == "" {
+		return fmt.Errorf("created_by boş olamaz")
+	}
+	if sil.Comment == "" {
+		return fmt.Errorf("comment boş olamaz")
+	}
+	if sil.Rule == "" && len(sil.Labels) == 0 {
+		return fmt.Errorf("rule veya labels belirtilmeli (tüm alarmlar için rule: \"*\")")
+	}
+	if sil.Rule != "" {
+		if _, err := path.Match(sil.Rule, ""); err != nil {
+			return fmt.Errorf("geçersiz rule deseni: %q", sil.Rule)
+		}
+	}
+	if !sil.EndsAt.After(sil.StartsAt) {
+		return fmt.Errorf("ends_at starts_at'tan sonra olmalı")
+	}
+	if !sil.EndsAt.After(now) {
+		return fmt.Errorf("ends_at geçmişte olamaz")
+	}
+	return nil
+}
+
+// newID rastgele susturma ID'si üretir
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
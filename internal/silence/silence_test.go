@@ -0,0 +1,118 @@
+package silence
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/alert"
+	"github.com/karsterr/syswatch-daemon/internal/config"
+)
+
+func diskAlert(mountpoint string) alert.Alert {
+	return alert.Alert{
+		Rule:   "disk_full",
+		Metric: "disk.filesystems." + mountpoint + ".usage",
+		State:  alert.StateFiring,
+		Labels: map[string]string{"instance": mountpoint},
+	}
+}
+
+func TestSilencePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "silences.json")
+	s, err := New(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	sil, err := s.Add(Silence{
+		Rule:      "disk_*",
+		Labels:    map[string]string{"instance": "/data"},
+		EndsAt:    now.Add(time.Hour),
+		CreatedBy: "ops",
+		Comment:   "patch night",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sil.ID == "" || sil.StartsAt.IsZero() {
+		t.Errorf("Expected ID and start time to be filled: %+v", sil)
+	}
+
+	if got := s.Silenced(diskAlert("/data"), now.Add(time.Minute)); got != sil.ID {
+		t.Errorf("Expected alert to be silenced by %s, got %q", sil.ID, got)
+	}
+	if got := s.Silenced(diskAlert("/"), now.Add(time.Minute)); got != "" {
+		t.Errorf("Expected other mountpoint not to be silenced, got %q", got)
+	}
+	if got := s.Silenced(diskAlert("/data"), now.Add(2*time.Hour)); got != "" {
+		t.Errorf("Expected expired silence not to match, got %q", got)
+	}
+
+	// Yeniden açılışta susturma dosyadan yüklenmeli
+	reopened, err := New(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := reopened.List(false); len(list) != 1 || list[0].ID != sil.ID || list[0].Comment != "patch night" {
+		t.Fatalf("Expected silence to survive restart, got %+v", list)
+	}
+
+	if err := reopened.Delete(sil.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Delete(sil.ID); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	again, _ := New(path, nil)
+	if len(again.List(true)) != 0 {
+		t.Error("Expected deletion to be persisted")
+	}
+}
+
+func TestSilenceValidation(t *testing.T) {
+	s, _ := New("", nil)
+	now := time.Now()
+
+	invalid := []Silence{
+		{Rule: "x", EndsAt: now.Add(time.Hour), Comment: "no author"},
+		{Rule: "x", EndsAt: now.Add(time.Hour), CreatedBy: "ops"},
+		{EndsAt: now.Add(time.Hour), CreatedBy: "ops", Comment: "matches everything"},
+		{Rule: "x", StartsAt: now.Add(time.Hour), EndsAt: now, CreatedBy: "ops", Comment: "reversed"},
+		{Rule: "[", EndsAt: now.Add(time.Hour), CreatedBy: "ops", Comment: "bad pattern"},
+	}
+	for i, sil := range invalid {
+		if _, err := s.Add(sil); err == nil {
+			t.Errorf("Case %d: expected validation error", i)
+		}
+	}
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	s, err := New("", []config.MaintenanceWindowConfig{
+		{Name: "patch-night", Schedule: "0 2 * * 0", Duration: 3600, Rules: []string{"disk_full"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2024-03-10 pazar
+	inside := time.Date(2024, time.March, 10, 2, 30, 0, 0, time.Local)
+	outside := time.Date(2024, time.March, 10, 3, 30, 0, 0, time.Local)
+
+	if got := s.Silenced(diskAlert("/"), inside); got != "maintenance:patch-night" {
+		t.Errorf("Expected alert to be silenced by maintenance window, got %q", got)
+	}
+	if got := s.Silenced(diskAlert("/"), outside); got != "" {
+		t.Errorf("Expected no silence outside window, got %q", got)
+	}
+	if got := s.Silenced(alert.Alert{Rule: "high_cpu"}, inside); got != "" {
+		t.Errorf("Expected other rules not to be silenced, got %q", got)
+	}
+
+	w := s.Windows(inside)[0]
+	if !w.Active || w.EndsAt == nil || !w.EndsAt.Equal(inside.Add(30*time.Minute)) {
+		t.Errorf("Unexpected window status: %+v", w)
+	}
+}
@@ -0,0 +1,342 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Kayıt türleri (frame payload'unun ilk byte'ı)
+const (
+	recordFields byte = 1 // Sözlüğe eklenen yeni alan isimleri
+	recordSample byte = 2 // Tek bir zaman noktasının değerleri
+)
+
+// frameHeaderSize uzunluk (4 byte) + CRC32 (4 byte)
+const frameHeaderSize = 8
+
+// maxFrameSize bozuk bir uzunluk alanının dev bir allocation'a yol açmasını engeller
+const maxFrameSize = 64 << 20
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	// errCorruptFrame frame'in CRC'si tutmadığında veya eksik yazıldığında döner
+	errCorruptFrame = errors.New("bozuk veya yarım kalmış kayıt")
+)
+
+// writeFrame payload'u uzunluk ve CRC ile çerçeveleyerek yazar
+func writeFrame(w io.Writer, payload []byte) error {
+	var header [frameHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:8], crc32.Checksum(payload, crcTable))
+
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// readFrames r'deki frame'leri sırayla okur ve her payload için fn'i çağırır.
+// Son sağlam frame'in bittiği offset'i döndürür; eksik veya bozuk bir frame'de
+// errCorruptFrame ile durur, böylece çağıran dosyayı bu offset'e kırpabilir.
+func readFrames(r io.Reader, fn func(payload []byte) error) (int64, error) {
+	br := bufio.NewReader(r)
+	var offset int64
+	var header [frameHeaderSize]byte
+
+	for {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if err == io.EOF {
+				return offset, nil
+			}
+			return offset, errCorruptFrame
+		}
+
+		size := binary.LittleEndian.Uint32(header[0:4])
+		if size > maxFrameSize {
+			return offset, errCorruptFrame
+		}
+
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return offset, errCorruptFrame
+		}
+		if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(header[4:8]) {
+			return offset, errCorruptFrame
+		}
+
+		if err := fn(payload); err != nil {
+			return offset, err
+		}
+		offset += int64(frameHeaderSize) + int64(size)
+	}
+}
+
+// chunk bir zaman aralığına ait kayıtları sözlük kodlamasıyla tutar.
+// Hem bellekteki head hem de diskten okunan block'lar bu yapıyı kullanır.
+type chunk struct {
+	names []string       // Alan id → isim
+	index map[string]int // İsim → alan id
+	times []int64        // Unix milisaniye, artan sırada
+	rows  [][]float64    // Her kayıt için alan id'sine göre değerler (eksikler NaN)
+}
+
+// newChunk boş bir chunk oluşturur
+func newChunk() *chunk {
+	return &chunk{index: make(map[string]int)}
+}
+
+// len chunk'taki kayıt sayısı
+func (c *chunk) len() int {
+	return len(c.times)
+}
+
+// minTime en eski kaydın zamanı
+func (c *chunk) minTime() int64 {
+	return c.times[0]
+}
+
+// maxTime en yeni kaydın zamanı
+func (c *chunk) maxTime() int64 {
+	return c.times[len(c.times)-1]
+}
+
+// encodeSample değerleri WAL'a yazılacak payload'lara dönüştürür; chunk
+// değiştirilmez. Yeni alan isimleri varsa önce bir alan kaydı üretilir. Dönen
+// isimler ve satır, WAL yazıldıktan sonra appendSample ile head'e uygulanır.
+func (c *chunk) encodeSample(ts int64, values map[string]float64) (payloads [][]byte, newNames []string, row []float64) {
+	// Deterministik id ataması için yeni isimler sıralanır
+	for name := range values {
+		if _, ok := c.index[name]; !ok {
+			newNames = append(newNames, name)
+		}
+	}
+	sort.Strings(newNames)
+
+	// Yeni alanlar addFields'taki sırayla sözlüğün sonuna eklenecek
+	ids := make(map[string]int, len(newNames))
+	for i, name := range newNames {
+		ids[name] = len(c.names) + i
+	}
+
+	row = make([]float64, len(c.names)+len(newNames))
+	for i := range row {
+		row[i] = math.NaN()
+	}
+	for name, v := range values {
+		id, ok := c.index[name]
+		if !ok {
+			id = ids[name]
+		}
+		row[id] = v
+	}
+
+	if len(newNames) > 0 {
+		payloads = append(payloads, encodeFields(newNames))
+	}
+	payloads = append(payloads, encodeRow(ts, row))
+	return payloads, newNames, row
+}
+
+// appendSample encodeSample ile kodlanan kaydı chunk'a ekler
+func (c *chunk) appendSample(ts int64, newNames []string, row []float64) {
+	c.addFields(newNames)
+	c.times = append(c.times, ts)
+	c.rows = append(c.rows, row)
+}
+
+// encodeAll chunk'ın tamamını block dosyası için payload listesine dönüştürür
+func (c *chunk) encodeAll() [][]byte {
+	payloads := make([][]byte, 0, len(c.times)+1)
+	if len(c.names) > 0 {
+		payloads = append(payloads, encodeFields(c.names))
+	}
+	for i, ts := range c.times {
+		payloads = append(payloads, encodeRow(ts, c.rows[i]))
+	}
+	return payloads
+}
+
+// apply WAL veya block'tan okunan bir payload'u chunk'a uygular
+func (c *chunk) apply(payload []byte) error {
+	if len(payload) == 0 {
+		return fmt.Errorf("boş kayıt")
+	}
+
+	r := &byteReader{buf: payload[1:]}
+	switch payload[0] {
+	case recordFields:
+		count := r.uvarint()
+		names := make([]string, 0, count)
+		for i := uint64(0); i < count && r.err == nil; i++ {
+			names = append(names, r.string())
+		}
+		if r.err != nil {
+			return r.err
+		}
+		c.addFields(names)
+
+	case recordSample:
+		ts := r.varint()
+		count := r.uvarint()
+		row := make([]float64, len(c.names))
+		for i := range row {
+			row[i] = math.NaN()
+		}
+		for i := uint64(0); i < count && r.err == nil; i++ {
+			id := r.uvarint()
+			v := r.float64()
+			if id >= uint64(len(row)) {
+				return fmt.Errorf("bilinmeyen alan id: %d", id)
+			}
+			row[id] = v
+		}
+		if r.err != nil {
+			return r.err
+		}
+		c.times = append(c.times, ts)
+		c.rows = append(c.rows, row)
+
+	default:
+		return fmt.Errorf("bilinmeyen kayıt türü: %d", payload[0])
+	}
+
+	return nil
+}
+
+// addFields sözlüğe yeni alan isimleri ekler
+func (c *chunk) addFields(names []string) {
+	for _, name := range names {
+		if _, ok := c.index[name]; ok {
+			continue
+		}
+		c.index[name] = len(c.names)
+		c.names = append(c.names, name)
+	}
+}
+
+// value kayıt satırındaki alan değerini döndürür
+func (c *chunk) value(row []float64, field string) (float64, bool) {
+	id, ok := c.index[field]
+	if !ok || id >= len(row) || math.IsNaN(row[id]) {
+		return 0, false
+	}
+	return row[id], true
+}
+
+// encodeFields alan kaydı payload'u oluşturur
+func encodeFields(names []string) []byte {
+	buf := []byte{recordFields}
+	buf = binary.AppendUvarint(buf, uint64(len(names)))
+	for _, name := range names {
+		buf = binary.AppendUvarint(buf, uint64(len(name)))
+		buf = append(buf, name...)
+	}
+	return buf
+}
+
+// encodeRow örnek kaydı payload'u oluşturur; NaN (eksik) değerler yazılmaz
+func encodeRow(ts int64, row []float64) []byte {
+	count := 0
+	for _, v := range row {
+		if !math.IsNaN(v) {
+			count++
+		}
+	}
+
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64*2+count*(binary.MaxVarintLen32+8))
+	buf = append(buf, recordSample)
+	buf = binary.AppendVarint(buf, ts)
+	buf = binary.AppendUvarint(buf, uint64(count))
+	for id, v := range row {
+		if math.IsNaN(v) {
+			continue
+		}
+		buf = binary.AppendUvarint(buf, uint64(id))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+	return buf
+}
+
+// byteReader payload çözümlemesi için basit okuyucu; ilk hatadan sonra sıfır değer döner
+type byteReader struct {
+	buf []byte
+	err error
+}
+
+func (r *byteReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errCorruptFrame
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *byteReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.err = errCorruptFrame
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *byteReader) float64() float64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.buf) < 8 {
+		r.err = errCorruptFrame
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(r.buf))
+	r.buf = r.buf[8:]
+	return v
+}
+
+func (r *byteReader) string() string {
+	size := r.uvarint()
+	if r.err != nil {
+		return ""
+	}
+	if uint64(len(r.buf)) < size {
+		r.err = errCorruptFrame
+		return ""
+	}
+	s := string(r.buf[:size])
+	r.buf = r.buf[size:]
+	return s
+}
+
+// record history sorgularında kullanılan tek bir chunk satırı
+type record struct {
+	c   *chunk
+	idx int
+}
+
+// Time kaydın zamanını döndürür
+func (r record) Time() time.Time {
+	return time.UnixMilli(r.c.times[r.idx])
+}
+
+// Value kayıttaki alan değerini döndürür
+func (r record) Value(field string) (float64, bool) {
+	return r.c.value(r.c.rows[r.idx], field)
+}
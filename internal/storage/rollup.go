@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/history"
+)
+
+// rollupDir rollup katmanlarının veri dizini
+const rollupDir = "rollup"
+
+// rollupDelay bir aralık kapandıktan sonra geç yazılan örnekler için beklenen süre
+const rollupDelay = 30 * time.Second
+
+// tierBlockRows katman block'larının kapsadığı özet sayısı (1m → 12 saat, 1h → 30 gün)
+const tierBlockRows = 720
+
+// Özet istatistikleri katman veritabanında "<alan>#<istatistik>" isimleriyle saklanır
+const (
+	statSep   = "#"
+	statMin   = "min"
+	statMax   = "max"
+	statAvg   = "avg"
+	statLast  = "last"
+	statP95   = "p95"
+	statCount = "count"
+)
+
+// RollupTier bir özetleme katmanının ayarları
+type RollupTier struct {
+	Resolution time.Duration // Her özetin kapsadığı aralık (ör. 1 dakika)
+	Retention  time.Duration // Bu süreden eski özetler silinir (0 = süresiz)
+}
+
+// tier ham verilerin sabit aralıklarla özetlendiği katman.
+// Her katman kendi WAL ve block'larına sahip ayrı bir veritabanıdır.
+type tier struct {
+	resolution time.Duration
+	db         *DB
+}
+
+// openTiers katman veritabanlarını çözünürlüğe göre artan sırada açar
+func openTiers(dir string, specs []RollupTier) ([]*tier, error) {
+	specs = append([]RollupTier(nil), specs...)
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Resolution < specs[j].Resolution })
+
+	var tiers []*tier
+	for _, spec := range specs {
+		if spec.Resolution <= 0 {
+			closeTiers(tiers)
+			return nil, fmt.Errorf("geçersiz rollup çözünürlüğü: %s", spec.Resolution)
+		}
+
+		name := strconv.FormatInt(int64(spec.Resolution/time.Second), 10) + "s"
+		db, err := Open(filepath.Join(dir, rollupDir, name), Options{
+			BlockDuration: spec.Resolution * tierBlockRows,
+			Retention:     spec.Retention,
+		})
+		if err != nil {
+			closeTiers(tiers)
+			return nil, fmt.Errorf("rollup katmanı açılamadı (%s): %w", spec.Resolution, err)
+		}
+		db.summary = true
+		tiers = append(tiers, &tier{resolution: spec.Resolution, db: db})
+	}
+	return tiers, nil
+}
+
+// closeTiers açılmış katmanları kapatır
+func closeTiers(tiers []*tier) {
+	for _, t := range tiers {
+		t.db.Close()
+	}
+}
+
+// pickTier step'i karşılayan (çözünürlüğü step'ten büyük olmayan) ve veri
+// içeren en kaba katmanı döndürür; uygun katman yoksa nil döner
+func (db *DB) pickTier(step time.Duration) *tier {
+	for i := len(db.tiers) - 1; i >= 0; i-- {
+		t := db.tiers[i]
+		if t.resolution > step {
+			continue
+		}
+		t.db.mu.RLock()
+		_, _, ok := t.db.bounds()
+		t.db.mu.RUnlock()
+		if ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// Rollup tamamlanmış aralıkları ham verilerden her katmana özetler.
+// Daemon tarafından periyodik olarak çağrılır; kaldığı yerden devam eder.
+func (db *DB) Rollup(now time.Time) error {
+	for _, t := range db.tiers {
+		if err := db.rollupTier(t, now); err != nil {
+			return fmt.Errorf("rollup başarısız (%s): %w", t.resolution, err)
+		}
+	}
+	return nil
+}
+
+// rollupTier katmanın son özetinden sonraki tamamlanmış aralıkları özetler
+func (db *DB) rollupTier(t *tier, now time.Time) error {
+	res := t.resolution.Milliseconds()
+	end := alignDown(now.Add(-rollupDelay).UnixMilli(), res)
+
+	t.db.mu.RLock()
+	_, last, ok := t.db.bounds()
+	t.db.mu.RUnlock()
+
+	next := last + res
+	if !ok {
+		db.mu.RLock()
+		first, _, hasData := db.bounds()
+		db.mu.RUnlock()
+		if !hasData {
+			return nil
+		}
+		next = alignDown(first, res)
+	}
+
+	// Uzun bir boşluk kapatılırken belleği sınırlamak için pencere pencere ilerlenir
+	window := alignDown(db.opts.BlockDuration.Milliseconds(), res)
+	if window < res {
+		window = res
+	}
+
+	for next < end {
+		stop := next + window
+		if stop > end {
+			stop = end
+		}
+
+		rows, err := db.summarize(next, stop, res)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := t.db.AppendValues(time.UnixMilli(row.ts), row.values); err != nil {
+				return err
+			}
+		}
+		next = stop
+	}
+	return nil
+}
+
+// summaryRow bir katman aralığının özet değerleri
+type summaryRow struct {
+	ts     int64
+	values map[string]float64
+}
+
+// summarize [from, to) aralığındaki ham kayıtları res uzunluğundaki aralıklara özetler
+func (db *DB) summarize(from, to, res int64) ([]summaryRow, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	chunks, err := db.chunks(from, to-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Aralık → alan → zaman sırasındaki değerler
+	buckets := make(map[int64]map[string][]float64)
+	for _, c := range chunks {
+		for i, ts := range c.times {
+			if ts < from || ts >= to {
+				continue
+			}
+			bucket := alignDown(ts, res)
+			fields := buckets[bucket]
+			if fields == nil {
+				fields = make(map[string][]float64)
+				buckets[bucket] = fields
+			}
+			for id, v := range c.rows[i] {
+				if !math.IsNaN(v) {
+					fields[c.names[id]] = append(fields[c.names[id]], v)
+				}
+			}
+		}
+	}
+
+	rows := make([]summaryRow, 0, len(buckets))
+	for ts, fields := range buckets {
+		values := make(map[string]float64, len(fields)*6)
+		for field, vs := range fields {
+			s := history.Summarize(vs)
+			values[statField(field, statMin)] = s.Min
+			values[statField(field, statMax)] = s.Max
+			values[statField(field, statAvg)] = s.Avg()
+			values[statField(field, statLast)] = s.Last
+			values[statField(field, statP95)] = s.P95
+			values[statField(field, statCount)] = float64(s.Count)
+		}
+		rows = append(rows, summaryRow{ts: ts, values: values})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ts < rows[j].ts })
+
+	return rows, nil
+}
+
+// summaryRecord katman veritabanındaki bir özet kaydı
+type summaryRecord struct {
+	record
+}
+
+// Value özetin ortalamasını döndürür
+func (r summaryRecord) Value(field string) (float64, bool) {
+	return r.stat(field, statAvg)
+}
+
+// Summary alanın özetini döndürür
+func (r summaryRecord) Summary(field string) (history.Summary, bool) {
+	count, ok := r.stat(field, statCount)
+	if !ok || count <= 0 {
+		return history.Summary{}, false
+	}
+
+	min, _ := r.stat(field, statMin)
+	max, _ := r.stat(field, statMax)
+	avg, _ := r.stat(field, statAvg)
+	last, _ := r.stat(field, statLast)
+	p95, _ := r.stat(field, statP95)
+
+	return history.Summary{
+		Min:   min,
+		Max:   max,
+		Sum:   avg * count,
+		Last:  last,
+		P95:   p95,
+		Count: int(count),
+	}, true
+}
+
+// stat kayıttaki bir istatistiğin değerini döndürür
+func (r summaryRecord) stat(field, stat string) (float64, bool) {
+	return r.c.value(r.c.rows[r.idx], statField(field, stat))
+}
+
+// statField özet istatistiğinin saklandığı alan ismini döndürür
+func statField(field, stat string) string {
+	return field + statSep + stat
+}
+
+// alignDown ts'yi step'in katına aşağı yuvarlar
+func alignDown(ts, step int64) int64 {
+	return ts - ts%step
+}
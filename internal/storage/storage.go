@@ -0,0 +1,611 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/history"
+	"github.com/karsterr/syswatch-daemon/internal/logger"
+	"github.com/karsterr/syswatch-daemon/internal/metrics"
+)
+
+// Dizin ve dosya isimleri
+const (
+	walDir      = "wal"
+	blocksDir   = "blocks"
+	walExt      = ".wal"
+	blockExt    = ".blk"
+	tmpExt      = ".tmp"
+	blockPrefix = "blk-"
+)
+
+// Options depolama motorunun ayarları
+type Options struct {
+	BlockDuration time.Duration // Her block'un kapsadığı zaman aralığı
+	Retention     time.Duration // Bu süreden eski block'lar silinir (0 = süresiz)
+	MaxBytes      int64         // Ham block'ların toplam boyut sınırı (0 = sınırsız)
+	Rollups       []RollupTier  // Uzun dönem geçmiş için özetleme katmanları
+}
+
+// DB snapshot'ları diskte saklayan gömülü zaman serisi veritabanı.
+//
+// Yazmalar önce aktif bölümün WAL dosyasına eklenir ve fsync edilir, ardından
+// bellekteki head chunk'a uygulanır. Bölüm süresi dolduğunda head sıkıştırılmış,
+// değişmez bir block dosyasına yazılır ve WAL silinir. Açılışta WAL'lar yeniden
+// oynatılarak çökme öncesi veriler kurtarılır.
+type DB struct {
+	mu   sync.RWMutex
+	dir  string
+	opts Options
+
+	head          *chunk
+	headPartition int64 // Head'in ait olduğu bölümün başlangıcı (unix ms)
+	wal           walFile
+	walSize       int64 // WAL'ın son başarılı yazmadan sonraki boyutu
+
+	blocks []blockMeta // Zaman sırasına göre
+	closed bool
+
+	tiers   []*tier // Çözünürlüğe göre artan sırada rollup katmanları
+	summary bool    // Kayıtlar rollup özeti mi (katman veritabanları için)
+}
+
+// walFile head'in WAL dosyası (testlerde hata döndüren sarmalayıcılar kullanılır)
+type walFile interface {
+	io.Writer
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// blockMeta diskteki bir block dosyasının bilgileri
+type blockMeta struct {
+	path       string
+	minT, maxT int64 // Unix ms
+	size       int64
+}
+
+// Open verilen dizindeki veritabanını açar ve gerekirse çökme kurtarması yapar
+func Open(dir string, opts Options) (*DB, error) {
+	if opts.BlockDuration <= 0 {
+		opts.BlockDuration = 2 * time.Hour
+	}
+
+	for _, sub := range []string{walDir, blocksDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("veri dizini oluşturulamadı: %w", err)
+		}
+	}
+
+	db := &DB{dir: dir, opts: opts}
+
+	if err := db.loadBlocks(); err != nil {
+		return nil, err
+	}
+	if err := db.recoverWAL(); err != nil {
+		return nil, err
+	}
+	db.enforceRetention(time.Now())
+
+	tiers, err := openTiers(dir, opts.Rollups)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	db.tiers = tiers
+
+	logger.GetLogger().Infof("Depolama açıldı: %s (%d block)", dir, len(db.blocks))
+	return db, nil
+}
+
+// Append snapshot'ı WAL'a yazar ve head'e ekler
+func (db *DB) Append(m *metrics.SystemMetrics) error {
+	return db.AppendValues(m.Timestamp, m.Flatten())
+}
+
+// AppendValues verilen zaman noktasındaki alan değerlerini saklar
+func (db *DB) AppendValues(t time.Time, values map[string]float64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return fmt.Errorf("depolama kapatıldı")
+	}
+
+	ts := t.UnixMilli()
+	partition := db.partitionOf(ts)
+
+	if db.head != nil {
+		if partition < db.headPartition || ts < db.head.maxTimeOrZero() {
+			return fmt.Errorf("sıra dışı kayıt reddedildi: %s", t.Format(time.RFC3339))
+		}
+		if partition > db.headPartition {
+			if err := db.flushHead(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if db.head == nil {
+		if err := db.openHead(partition); err != nil {
+			return err
+		}
+	}
+
+	// Head, kayıt WAL'a yazılıp fsync edildikten sonra güncellenir; aksi halde
+	// head'deki alan id'leri ve satırlar WAL'dan yeniden oynatılanlarla uyuşmaz
+	payloads, newNames, row := db.head.encodeSample(ts, values)
+	var buf bytes.Buffer
+	for _, payload := range payloads {
+		writeFrame(&buf, payload)
+	}
+	if _, err := db.wal.Write(buf.Bytes()); err != nil {
+		return db.rollbackWAL(fmt.Errorf("WAL yazılamadı: %w", err))
+	}
+	if err := db.wal.Sync(); err != nil {
+		return db.rollbackWAL(fmt.Errorf("WAL fsync başarısız: %w", err))
+	}
+	db.walSize += int64(buf.Len())
+	db.head.appendSample(ts, newNames, row)
+
+	return nil
+}
+
+// rollbackWAL başarısız yazmada WAL'ı son başarılı kaydın sonuna kırpar.
+// Yarım kalan frame bırakılırsa açılışta sonraki tüm kayıtlar atılır.
+func (db *DB) rollbackWAL(err error) error {
+	if terr := db.wal.Truncate(db.walSize); terr != nil {
+		return fmt.Errorf("%w (WAL geri alınamadı: %v)", err, terr)
+	}
+	return err
+}
+
+// Query history sorgusunu yanıtlar. Step'i karşılayan en kaba rollup katmanı
+// kullanılır; katmanın henüz özetlemediği son kısım ham verilerden tamamlanır.
+func (db *DB) Query(q history.Query) (*history.Result, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	t := db.pickTier(q.Step)
+	if t != nil {
+		t.db.mu.RLock()
+		defer t.db.mu.RUnlock()
+	}
+
+	// Head kayıtları okunurken eklenmesin diye kilit sorgu boyunca tutulur
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	from := q.From
+	var records []history.Record
+	if t != nil {
+		rolled, err := t.db.records(q.From, q.To)
+		if err != nil {
+			return nil, err
+		}
+		records = rolled
+		if _, maxT, ok := t.db.bounds(); ok {
+			if next := time.UnixMilli(maxT).Add(t.resolution); next.After(from) {
+				from = next
+			}
+		}
+	}
+
+	raw, err := db.records(from, q.To)
+	if err != nil {
+		return nil, err
+	}
+	return history.Aggregate(append(records, raw...), q), nil
+}
+
+// records [from, to] aralığındaki tüm kayıtları eskiden yeniye döndürür.
+// Çağıran en azından okuma kilidini tutmalıdır.
+func (db *DB) records(from, to time.Time) ([]history.Record, error) {
+	minT, maxT := from.UnixMilli(), to.UnixMilli()
+	if minT > maxT {
+		return nil, nil
+	}
+
+	chunks, err := db.chunks(minT, maxT)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []history.Record
+	for _, c := range chunks {
+		for i, ts := range c.times {
+			if ts < minT || ts > maxT {
+				continue
+			}
+			if db.summary {
+				records = append(records, summaryRecord{record{c: c, idx: i}})
+			} else {
+				records = append(records, record{c: c, idx: i})
+			}
+		}
+	}
+	return records, nil
+}
+
+// chunks [minT, maxT] aralığıyla kesişen block'ları ve head'i döndürür.
+// Çağıran en azından okuma kilidini tutmalıdır.
+func (db *DB) chunks(minT, maxT int64) ([]*chunk, error) {
+	var chunks []*chunk
+	for _, b := range db.blocks {
+		if b.maxT < minT || b.minT > maxT {
+			continue
+		}
+		c, err := readBlock(b.path)
+		if err != nil {
+			return nil, fmt.Errorf("block okunamadı (%s): %w", filepath.Base(b.path), err)
+		}
+		chunks = append(chunks, c)
+	}
+	if db.head != nil && db.head.len() > 0 {
+		chunks = append(chunks, db.head)
+	}
+	return chunks, nil
+}
+
+// bounds saklanan en eski ve en yeni kaydın zamanını döndürür.
+// Çağıran en azından okuma kilidini tutmalıdır.
+func (db *DB) bounds() (minT, maxT int64, ok bool) {
+	if len(db.blocks) > 0 {
+		minT, maxT, ok = db.blocks[0].minT, db.blocks[len(db.blocks)-1].maxT, true
+	}
+	if db.head != nil && db.head.len() > 0 {
+		if !ok {
+			minT = db.head.minTime()
+		}
+		maxT, ok = db.head.maxTime(), true
+	}
+	return minT, maxT, ok
+}
+
+// Close WAL dosyalarını kapatır; head verileri WAL'da kalır ve açılışta kurtarılır
+func (db *DB) Close() error {
+	var firstErr error
+	for _, t := range db.tiers {
+		if err := t.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.closed = true
+	if db.wal == nil {
+		return firstErr
+	}
+	if err := db.wal.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	db.wal = nil
+	db.head = nil
+	return firstErr
+}
+
+// Size block'ların diskteki toplam boyutunu döndürür
+func (db *DB) Size() int64 {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var total int64
+	for _, b := range db.blocks {
+		total += b.size
+	}
+	return total
+}
+
+// partitionOf zaman damgasının ait olduğu bölümün başlangıcını döndürür
+func (db *DB) partitionOf(ts int64) int64 {
+	return alignDown(ts, db.opts.BlockDuration.Milliseconds())
+}
+
+// maxTimeOrZero boş chunk için sıfır döndürür
+func (c *chunk) maxTimeOrZero() int64 {
+	if c.len() == 0 {
+		return 0
+	}
+	return c.maxTime()
+}
+
+// walPath bölümün WAL dosyasının yolunu döndürür
+func (db *DB) walPath(partition int64) string {
+	return filepath.Join(db.dir, walDir, strconv.FormatInt(partition, 10)+walExt)
+}
+
+// openHead yeni bir bölüm için head ve WAL dosyası açar
+func (db *DB) openHead(partition int64) error {
+	f, err := os.OpenFile(db.walPath(partition), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("WAL açılamadı: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("WAL açılamadı: %w", err)
+	}
+	db.wal = f
+	db.walSize = info.Size()
+	db.head = newChunk()
+	db.headPartition = partition
+	return nil
+}
+
+// flushHead head'i block dosyasına yazar ve WAL'ı siler
+func (db *DB) flushHead() error {
+	if db.head.len() > 0 {
+		meta, err := writeBlock(filepath.Join(db.dir, blocksDir), db.head)
+		if err != nil {
+			return fmt.Errorf("block yazılamadı: %w", err)
+		}
+		db.blocks = append(db.blocks, meta)
+		sortBlocks(db.blocks)
+	}
+
+	if err := db.wal.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(db.walPath(db.headPartition)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	db.wal = nil
+	db.head = nil
+	db.enforceRetention(time.Now())
+	return nil
+}
+
+// loadBlocks block dizinini tarar; yarım kalmış geçici dosyaları temizler
+func (db *DB) loadBlocks() error {
+	dir := filepath.Join(db.dir, blocksDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	log := logger.GetLogger()
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if strings.HasSuffix(e.Name(), tmpExt) {
+			log.Warnf("Yarım kalmış block siliniyor: %s", e.Name())
+			os.Remove(path)
+			continue
+		}
+
+		meta, ok := parseBlockName(e.Name())
+		if !ok {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		meta.path = path
+		meta.size = info.Size()
+		db.blocks = append(db.blocks, meta)
+	}
+
+	sortBlocks(db.blocks)
+	return nil
+}
+
+// recoverWAL WAL dosyalarını oynatır. Bozuk kuyruklar kırpılır, eski bölümler
+// block'a dönüştürülür ve en yeni bölüm head olarak açılır.
+func (db *DB) recoverWAL() error {
+	dir := filepath.Join(db.dir, walDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var partitions []int64
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasSuffix(name, walExt) {
+			continue
+		}
+		p, err := strconv.ParseInt(strings.TrimSuffix(name, walExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	log := logger.GetLogger()
+	for i, p := range partitions {
+		c, err := replayWAL(db.walPath(p))
+		if err != nil {
+			return err
+		}
+		log.Infof("WAL kurtarıldı: %d kayıt (%s)", c.len(), filepath.Base(db.walPath(p)))
+
+		if err := db.openHead(p); err != nil {
+			return err
+		}
+		db.head = c
+
+		// Sadece en yeni bölüm açık kalır
+		if i < len(partitions)-1 {
+			if err := db.flushHead(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// replayWAL WAL dosyasını okur; bozuk veya yarım kalan kuyruğu kırpar
+func replayWAL(path string) (*chunk, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c := newChunk()
+	valid, err := readFrames(f, c.apply)
+	if err == errCorruptFrame {
+		logger.GetLogger().Warnf("WAL sonunda bozuk kayıt bulundu, %d byte'a kırpılıyor: %s", valid, filepath.Base(path))
+		if err := f.Truncate(valid); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("WAL okunamadı (%s): %w", filepath.Base(path), err)
+	}
+
+	return c, nil
+}
+
+// enforceRetention yaş ve toplam boyut sınırlarını aşan en eski block'ları siler
+func (db *DB) enforceRetention(now time.Time) {
+	log := logger.GetLogger()
+
+	remove := func(b blockMeta) {
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			log.Errorf("Block silinemedi (%s): %v", filepath.Base(b.path), err)
+		}
+	}
+
+	if db.opts.Retention > 0 {
+		cutoff := now.Add(-db.opts.Retention).UnixMilli()
+		for len(db.blocks) > 0 && db.blocks[0].maxT < cutoff {
+			log.Debugf("Saklama süresi dolan block siliniyor: %s", filepath.Base(db.blocks[0].path))
+			remove(db.blocks[0])
+			db.blocks = db.blocks[1:]
+		}
+	}
+
+	if db.opts.MaxBytes > 0 {
+		var total int64
+		for _, b := range db.blocks {
+			total += b.size
+		}
+		for len(db.blocks) > 0 && total > db.opts.MaxBytes {
+			log.Debugf("Disk bütçesi aşıldı, block siliniyor: %s", filepath.Base(db.blocks[0].path))
+			total -= db.blocks[0].size
+			remove(db.blocks[0])
+			db.blocks = db.blocks[1:]
+		}
+	}
+}
+
+// writeBlock chunk'ı gzip ile sıkıştırılmış block dosyasına atomik olarak yazar
+func writeBlock(dir string, c *chunk) (blockMeta, error) {
+	meta := blockMeta{minT: c.minTime(), maxT: c.maxTime()}
+	name := fmt.Sprintf("%s%d-%d%s", blockPrefix, meta.minT, meta.maxT, blockExt)
+	meta.path = filepath.Join(dir, name)
+	tmp := meta.path + tmpExt
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return meta, err
+	}
+
+	zw := gzip.NewWriter(f)
+	for _, payload := range c.encodeAll() {
+		if err := writeFrame(zw, payload); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return meta, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return meta, err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return meta, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return meta, err
+	}
+	meta.size = info.Size()
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return meta, err
+	}
+
+	if err := os.Rename(tmp, meta.path); err != nil {
+		os.Remove(tmp)
+		return meta, err
+	}
+	syncDir(dir)
+
+	return meta, nil
+}
+
+// readBlock block dosyasını chunk olarak okur
+func readBlock(path string) (*chunk, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	c := newChunk()
+	if _, err := readFrames(zr, c.apply); err != nil {
+		return nil, err
+	}
+	// gzip checksum'ının doğrulanması için okuyucu sonuna kadar tüketilir
+	if _, err := io.Copy(io.Discard, zr); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseBlockName "blk-<minT>-<maxT>.blk" isminden zaman aralığını çözer
+func parseBlockName(name string) (blockMeta, bool) {
+	if !strings.HasPrefix(name, blockPrefix) || !strings.HasSuffix(name, blockExt) {
+		return blockMeta{}, false
+	}
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, blockPrefix), blockExt), "-")
+	if len(parts) != 2 {
+		return blockMeta{}, false
+	}
+	minT, err1 := strconv.ParseInt(parts[0], 10, 64)
+	maxT, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return blockMeta{}, false
+	}
+	return blockMeta{minT: minT, maxT: maxT}, true
+}
+
+// sortBlocks block'ları başlangıç zamanına göre sıralar
+func sortBlocks(blocks []blockMeta) {
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].minT < blocks[j].minT })
+}
+
+// syncDir rename işleminin kalıcı olması için dizini fsync eder (desteklenmiyorsa yok sayılır)
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
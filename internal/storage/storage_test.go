@@ -0,0 +1,364 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/karsterr/syswatch-daemon/internal/history"
+)
+
+// avgAt sonuçtaki ilk serinin verilen adımdaki ortalamasını döndürür
+func avgAt(t *testing.T, res *history.Result, idx int) float64 {
+	t.Helper()
+	p := res.Series[0].Points[idx]
+	if p.Avg == nil {
+		t.Fatalf("Point %d has no data", idx)
+	}
+	return *p.Avg
+}
+
+// countPoints sonuçtaki ilk serinin toplam örnek sayısını döndürür
+func countPoints(res *history.Result) int {
+	total := 0
+	for _, p := range res.Series[0].Points {
+		total += p.Count
+	}
+	return total
+}
+
+func TestAppendQuery(t *testing.T) {
+	db, err := Open(t.TempDir(), Options{BlockDuration: time.Hour})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer db.Close()
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		values := map[string]float64{"cpu.usage": float64(i * 10)}
+		// Sonradan eklenen alan sözlüğe yeni bir kayıtla eklenmeli
+		if i >= 3 {
+			values["memory.usage"] = 50
+		}
+		if err := db.AppendValues(base.Add(time.Duration(i)*10*time.Second), values); err != nil {
+			t.Fatalf("Append %d failed: %v", i, err)
+		}
+	}
+
+	res, err := db.Query(history.Query{
+		From:   base,
+		To:     base.Add(59 * time.Second),
+		Step:   30 * time.Second,
+		Fields: []string{"cpu.usage", "memory.usage"},
+	})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	// İlk 30 saniye: 0, 10, 20 → avg 10; sonraki: 30, 40, 50 → avg 40
+	if got := avgAt(t, res, 0); got != 10 {
+		t.Errorf("Expected avg 10, got %.1f", got)
+	}
+	if got := avgAt(t, res, 1); got != 40 {
+		t.Errorf("Expected avg 40, got %.1f", got)
+	}
+	if mem := res.Series[1].Points; mem[0].Count != 0 || mem[1].Count != 3 {
+		t.Errorf("Expected memory counts 0/3, got %d/%d", mem[0].Count, mem[1].Count)
+	}
+
+	// Sıra dışı kayıt reddedilmeli
+	if err := db.AppendValues(base, map[string]float64{"cpu.usage": 1}); err == nil {
+		t.Error("Expected error for out-of-order sample")
+	}
+}
+
+func TestRecoverWAL(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	db, err := Open(dir, Options{BlockDuration: time.Hour})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		db.AppendValues(base.Add(time.Duration(i)*time.Second), map[string]float64{"cpu.usage": float64(i)})
+	}
+	db.Close()
+
+	if err := db.AppendValues(base.Add(time.Minute), map[string]float64{"cpu.usage": 1}); err == nil {
+		t.Error("Expected error when appending to closed storage")
+	}
+
+	// Çökmeyi taklit etmek için WAL'ın sonuna yarım bir frame ekle
+	walFile := filepath.Join(dir, walDir, "1704110400000"+walExt)
+	f, err := os.OpenFile(walFile, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("WAL not found: %v", err)
+	}
+	f.Write([]byte{0x20, 0, 0, 0, 1, 2})
+	f.Close()
+
+	db, err = Open(dir, Options{BlockDuration: time.Hour})
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer db.Close()
+
+	// Kırpılan WAL'a yazmaya devam edilebilmeli
+	if err := db.AppendValues(base.Add(5*time.Second), map[string]float64{"cpu.usage": 5}); err != nil {
+		t.Fatalf("Append after recovery failed: %v", err)
+	}
+
+	res, err := db.Query(history.Query{
+		From:   base,
+		To:     base.Add(10 * time.Second),
+		Step:   time.Minute,
+		Fields: []string{"cpu.usage"},
+	})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if got := countPoints(res); got != 6 {
+		t.Errorf("Expected 6 recovered samples, got %d", got)
+	}
+}
+
+// failingWAL yazma sırasında disk dolmuş gibi davranan WAL dosyası
+type failingWAL struct {
+	*os.File
+	fail bool
+}
+
+// Write fail açıksa verinin yarısını yazıp hata döndürür (yarım frame)
+func (w *failingWAL) Write(p []byte) (int, error) {
+	if w.fail {
+		n, _ := w.File.Write(p[:len(p)/2])
+		return n, errors.New("no space left on device")
+	}
+	return w.File.Write(p)
+}
+
+func TestAppendWALFailure(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	db, err := Open(dir, Options{BlockDuration: time.Hour})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := db.AppendValues(base, map[string]float64{"cpu.usage": 1}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	wal := &failingWAL{File: db.wal.(*os.File), fail: true}
+	db.wal = wal
+	if err := db.AppendValues(base.Add(time.Second), map[string]float64{"cpu.usage": 2, "memory.usage": 50}); err == nil {
+		t.Fatal("Expected error when WAL write fails")
+	}
+	if db.head.len() != 1 || len(db.head.names) != 1 {
+		t.Fatalf("Expected head to be unchanged after failed write, got %d samples and fields %v", db.head.len(), db.head.names)
+	}
+
+	// Yazma düzeldiğinde yeni alanlar WAL'a kendi alan kaydıyla yazılmalı
+	wal.fail = false
+	if err := db.AppendValues(base.Add(2*time.Second), map[string]float64{"cpu.usage": 3, "disk.usage": 70}); err != nil {
+		t.Fatalf("Append after failure failed: %v", err)
+	}
+	want := db.head.encodeAll()
+	db.Close()
+
+	db, err = Open(dir, Options{BlockDuration: time.Hour})
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer db.Close()
+
+	got := db.head.encodeAll()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d records after replay, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !bytes.Equal(got[i], want[i]) {
+			t.Errorf("Replayed record %d does not match head before restart", i)
+		}
+	}
+	if _, ok := db.head.index["memory.usage"]; ok {
+		t.Error("Expected field from failed write not to be replayed")
+	}
+}
+
+func TestBlockFlush(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	db, err := Open(dir, Options{BlockDuration: time.Hour})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	// Üç saatlik bölüme yayılan kayıtlar: ilk ikisi block'a yazılmalı
+	for i := 0; i < 6; i++ {
+		ts := base.Add(time.Duration(i) * 30 * time.Minute)
+		if err := db.AppendValues(ts, map[string]float64{"cpu.usage": float64(i)}); err != nil {
+			t.Fatalf("Append %d failed: %v", i, err)
+		}
+	}
+	if len(db.blocks) != 2 {
+		t.Fatalf("Expected 2 blocks, got %d", len(db.blocks))
+	}
+	if db.Size() == 0 {
+		t.Error("Expected non-zero block size")
+	}
+	db.Close()
+
+	wals, _ := os.ReadDir(filepath.Join(dir, walDir))
+	if len(wals) != 1 {
+		t.Errorf("Expected only the active WAL to remain, got %d", len(wals))
+	}
+
+	// Block'lar ve WAL yeniden açılışta birlikte okunmalı
+	db, err = Open(dir, Options{BlockDuration: time.Hour})
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer db.Close()
+
+	res, err := db.Query(history.Query{
+		From:   base,
+		To:     base.Add(3 * time.Hour),
+		Step:   time.Hour,
+		Fields: []string{"cpu.usage"},
+	})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if got := countPoints(res); got != 6 {
+		t.Errorf("Expected 6 samples, got %d", got)
+	}
+	if got := avgAt(t, res, 1); got != 2.5 {
+		t.Errorf("Expected second hour avg 2.5, got %.2f", got)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	db, err := Open(dir, Options{BlockDuration: time.Hour, Retention: 3 * time.Hour})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer db.Close()
+
+	// Son 6 saat için saatte bir kayıt; 3 saatten eski block'lar silinmeli
+	for i := 6; i >= 0; i-- {
+		db.AppendValues(now.Add(-time.Duration(i)*time.Hour), map[string]float64{"cpu.usage": float64(i)})
+	}
+
+	for _, b := range db.blocks {
+		if b.maxT < now.Add(-3*time.Hour).UnixMilli() {
+			t.Errorf("Block older than retention was kept: %s", filepath.Base(b.path))
+		}
+	}
+	files, _ := os.ReadDir(filepath.Join(dir, blocksDir))
+	if len(files) != len(db.blocks) {
+		t.Errorf("Expected %d block files on disk, got %d", len(db.blocks), len(files))
+	}
+
+	// Disk bütçesi en eski block'ları da silmeli
+	db.opts.MaxBytes = db.blocks[len(db.blocks)-1].size
+	db.enforceRetention(now)
+	if len(db.blocks) != 1 {
+		t.Errorf("Expected 1 block within byte budget, got %d", len(db.blocks))
+	}
+}
+
+func TestRollup(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	opts := Options{
+		BlockDuration: time.Hour,
+		Rollups: []RollupTier{
+			{Resolution: time.Hour},
+			{Resolution: time.Minute},
+		},
+	}
+
+	db, err := Open(dir, opts)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	// İki saat boyunca 10 saniyede bir 0..99 arasında dönen değerler
+	for i := 0; i < 720; i++ {
+		ts := base.Add(time.Duration(i) * 10 * time.Second)
+		if err := db.AppendValues(ts, map[string]float64{"cpu.usage": float64(i % 100)}); err != nil {
+			t.Fatalf("Append %d failed: %v", i, err)
+		}
+	}
+
+	if err := db.Rollup(base.Add(2*time.Hour + time.Minute)); err != nil {
+		t.Fatalf("Rollup failed: %v", err)
+	}
+	if got := db.tiers[0].db.head.len() + len(db.tiers[0].db.blocks); got == 0 {
+		t.Fatal("Expected minute tier to contain summaries")
+	}
+	db.Close()
+
+	// Yeniden açılışta kalınan yerden devam edilmeli (tekrar özetleme hata vermez)
+	db, err = Open(dir, opts)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer db.Close()
+	if err := db.Rollup(base.Add(2*time.Hour + time.Minute)); err != nil {
+		t.Fatalf("Second rollup failed: %v", err)
+	}
+
+	// Saatlik step en kaba katmandan karşılanmalı
+	if tier := db.pickTier(time.Hour); tier == nil || tier.resolution != time.Hour {
+		t.Fatalf("Expected hourly tier to be picked")
+	}
+	if tier := db.pickTier(30 * time.Second); tier != nil {
+		t.Fatalf("Expected raw data for sub-minute step, got %s tier", tier.resolution)
+	}
+
+	res, err := db.Query(history.Query{
+		From:   base,
+		To:     base.Add(2*time.Hour - time.Second),
+		Step:   time.Hour,
+		Fields: []string{"cpu.usage"},
+	})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	p := res.Series[0].Points[0]
+	if p.Count != 360 {
+		t.Fatalf("Expected 360 samples in first hour, got %d", p.Count)
+	}
+	if *p.Min != 0 || *p.Max != 99 || *p.Last != float64(359%100) {
+		t.Errorf("Unexpected summary: min %.0f max %.0f last %.0f", *p.Min, *p.Max, *p.Last)
+	}
+	if *p.P95 < 90 || *p.P95 > 99 {
+		t.Errorf("Expected p95 in [90, 99], got %.1f", *p.P95)
+	}
+
+	// Katmanda henüz olmayan yeni veriler ham verilerden tamamlanmalı
+	db.AppendValues(base.Add(2*time.Hour+30*time.Second), map[string]float64{"cpu.usage": 42})
+	res, err = db.Query(history.Query{
+		From:   base,
+		To:     base.Add(3 * time.Hour),
+		Step:   time.Hour,
+		Fields: []string{"cpu.usage"},
+	})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if got := countPoints(res); got != 721 {
+		t.Errorf("Expected 721 samples including raw tail, got %d", got)
+	}
+}